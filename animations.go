@@ -1,5 +1,7 @@
 package main
 
+import "math"
+
 // AnimationType represents the current animation being played
 type AnimationType int
 
@@ -41,6 +43,8 @@ type AnimationState struct {
 	Frame       int
 	Timer       float32
 	Queue       []AnimationType // Queued animations to play
+	Intensity   float32         // How big the current action is, 0 (normal) to 1
+	intensities []float32       // Intensity of each queued animation
 }
 
 // AnimationSystem manages Claude's animation state machine
@@ -135,20 +139,35 @@ func (a *AnimationSystem) HandleEvent(event Event) {
 		return
 	}
 
-	// Queue the animation
-	a.queueAnimation(newAnim)
+	// Queue the animation, bigger edits writing harder
+	var intensity float32
+	if event.Type == EventWriting {
+		intensity = editIntensity(event.LinesAdded + event.LinesRemoved)
+	}
+	a.queueAnimation(newAnim, intensity)
+}
+
+// editIntensity scales an edit's size to 0-1: a one-line fix barely
+// registers, a few hundred lines is as big as it gets
+func editIntensity(lines int) float32 {
+	if lines <= 0 {
+		return 0
+	}
+	return float32(math.Min(math.Log2(float64(lines)+1)/8, 1))
 }
 
 // queueAnimation adds an animation to the queue or plays immediately
-func (a *AnimationSystem) queueAnimation(anim AnimationType) {
+func (a *AnimationSystem) queueAnimation(anim AnimationType, intensity float32) {
 	// If idle, play immediately
 	if a.state.CurrentAnim == AnimIdle {
 		a.state.CurrentAnim = anim
+		a.state.Intensity = intensity
 		a.state.Frame = 0
 		a.state.Timer = 0
 	} else {
 		// Otherwise queue it
 		a.state.Queue = append(a.state.Queue, anim)
+		a.state.intensities = append(a.state.intensities, intensity)
 	}
 }
 
@@ -174,12 +193,15 @@ func (a *AnimationSystem) onAnimationComplete() {
 	// Check queue for next animation
 	if len(a.state.Queue) > 0 {
 		a.state.CurrentAnim = a.state.Queue[0]
+		a.state.Intensity = a.state.intensities[0]
 		a.state.Queue = a.state.Queue[1:]
+		a.state.intensities = a.state.intensities[1:]
 		a.state.Frame = 0
 	} else if a.loopMode {
 		// Loop mode: restart the same animation
 		a.state.Frame = 0
 	} else {
+		a.state.Intensity = 0
 		// Return to walk if in active walk mode, otherwise idle
 		if a.walkMode && a.isActive {
 			a.state.CurrentAnim = AnimWalk
@@ -245,6 +267,8 @@ func (a *AnimationSystem) SetAnimation(anim AnimationType) {
 	a.state.Frame = 0
 	a.state.Timer = 0
 	a.state.Queue = nil // Clear queue
+	a.state.intensities = nil
+	a.state.Intensity = 0
 }
 
 // GetAnimationLength returns the frame count for the current animation
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ============================================================================
+// BIOME PACKS
+// ============================================================================
+//
+// A biome is a JSON file: a sky gradient and a stack of parallax layers, back
+// to front. Each layer scrolls at its own rate and holds props - plain shapes,
+// the built-in scenery painters (trees, castles, bookshelves...) or a PNG
+// strip. Packs are read from assets/biomes and then ~/.claude-quest/biomes, so
+// new biomes don't need a rebuild. A user pack with the same file name as a
+// built-in one replaces it.
+
+const biomeSkyHeight = 100 // Default rows covered by the sky gradient
+
+// BiomePack is one biome definition
+type BiomePack struct {
+	ID     string       `json:"-"` // File name without .json
+	Name   string       `json:"name"`
+	Order  int          `json:"order"`  // Position in the cycle (packs without one go last)
+	Indoor bool         `json:"indoor"` // No sky: skip the night sky, fireflies and weather
+	Sky    BiomeSky     `json:"sky"`
+	Layers []BiomeLayer `json:"layers"`
+}
+
+// BiomeSky is a vertical gradient from the top of the screen down
+type BiomeSky struct {
+	Top    BiomeColor `json:"top"`
+	Bottom BiomeColor `json:"bottom"`
+	Height int32      `json:"height"`
+}
+
+// BiomeLayer is a set of props that scroll together
+type BiomeLayer struct {
+	Scroll float32     `json:"scroll"` // Parallax factor: 0 = fixed, 1 = moves with the ground
+	Repeat int32       `json:"repeat"` // Props repeat every this many pixels (0 = drawn once)
+	Start  *int32      `json:"start"`  // First tile's position (default -repeat)
+	Cycle  int32       `json:"cycle"`  // Props drawn once come back around after this many pixels
+	Wrap   bool        `json:"wrap"`   // Props drawn once wrap around the screen edges
+	Image  string      `json:"image"`  // PNG strip tiled across the screen, relative to the pack
+	Y      int32       `json:"y"`      // Top of the image strip
+	Props  []BiomeProp `json:"props"`
+
+	texture rl.Texture2D
+}
+
+// BiomeProp is one thing drawn in a layer. Which fields matter depends on the kind.
+type BiomeProp struct {
+	Kind   string       `json:"kind"`
+	X      int32        `json:"x"`
+	Y      int32        `json:"y"`
+	W      int32        `json:"w"`
+	H      int32        `json:"h"`
+	R      float32      `json:"r"`      // Circle radius
+	Size   int32        `json:"size"`   // Height of trees, crystals, boulders...
+	Count  int          `json:"count"`  // Number of motes
+	Glow   bool         `json:"glow"`   // Glowing variant of a tree or crystal
+	Color  BiomeColor   `json:"color"`  //
+	Colors []BiomeColor `json:"colors"` // Picked per tile instead of color
+	Pulse  *BiomePulse  `json:"pulse"`  // Animates the color
+	Phase  float32      `json:"phase"`  // Time offset for animated props
+	Every  int32        `json:"every"`  // Only on every Nth tile
+}
+
+// BiomePulse swings a prop's color towards another color and back
+type BiomePulse struct {
+	To    BiomeColor `json:"to"`
+	Speed float32    `json:"speed"`
+	Phase float32    `json:"phase"`
+}
+
+// BiomeColor is a color written as [r, g, b] or [r, g, b, a]
+type BiomeColor rl.Color
+
+// UnmarshalJSON reads a color array
+func (c *BiomeColor) UnmarshalJSON(data []byte) error {
+	var v []int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if len(v) != 3 && len(v) != 4 {
+		return fmt.Errorf("color needs 3 or 4 values, got %d", len(v))
+	}
+	for _, n := range v {
+		if n < 0 || n > 255 {
+			return fmt.Errorf("color value %d out of range", n)
+		}
+	}
+	*c = BiomeColor{R: uint8(v[0]), G: uint8(v[1]), B: uint8(v[2]), A: 255}
+	if len(v) == 4 {
+		c.A = uint8(v[3])
+	}
+	return nil
+}
+
+// biomePainter draws one prop at a screen position
+type biomePainter func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color)
+
+// biomePainters are the prop kinds a pack can use
+var biomePainters = map[string]biomePainter{
+	// Shapes
+	"rect": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.canvas.DrawRectangle(x, y, p.W, p.H, c)
+	},
+	"pixel": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.canvas.DrawPixel(x, y, c) },
+	"line": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.canvas.DrawLine(x, y, x+p.W, y+p.H, c)
+	},
+	"circle": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.canvas.DrawCircle(x, y, p.R, c) },
+	"ellipse": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.canvas.DrawEllipse(x, y, float32(p.W), float32(p.H), c)
+	},
+
+	// Shared scenery
+	"mountain": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawMountain(x, y, p.W, p.H, c) },
+	"hill":     func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawHill(x, y, p.W, p.H, c) },
+	"tree":     func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawTree(x, y, p.Size, c) },
+	"grass":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawGrass(x, y) },
+	"motes":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawMotes(p, x, y, t, c) },
+
+	// Enchanted forest
+	"magic_tree": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.drawMagicTree(x, y, p.Size, c, p.Glow)
+	},
+	"mushroom":  func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawMushroom(x, y, c) },
+	"fern":      func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawFern(x, y, t) },
+	"fireflies": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawFireflies(r.scrollOffset, t) },
+
+	// Mountain journey
+	"snow_mountain": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawSnowMountain(x, y, p.W, p.H) },
+	"rocky_mountain": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.drawRockyMountain(x, y, p.W, p.H)
+	},
+	"ruins":        func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawRuins(x, y) },
+	"waterfall":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawWaterfall(x, y, p.Size, t) },
+	"rocky_hill":   func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawRockyHill(x, y, p.W, p.H) },
+	"boulder":      func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawBoulder(x, y, p.Size) },
+	"alpine_plant": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawAlpinePlant(x, y) },
+
+	// Midnight quest
+	"crystal": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.drawCrystalFormation(x, y, p.Size, c, p.Glow)
+	},
+	"spooky_tree":      func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawSpookyTree(x, y, p.Size) },
+	"glowing_mushroom": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawGlowingMushroom(x, y, t) },
+	"small_crystal":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawSmallCrystal(x, y, t) },
+	"magic_particles": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.drawMagicParticles(r.scrollOffset, t)
+	},
+
+	// Kingdom road
+	"cloud":     func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawCloud(x, y) },
+	"castle":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawCastle(x, y) },
+	"farm_hill": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawFarmHill(x, y, p.W, p.H, c) },
+	"windmill":  func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawWindmill(x, y, t) },
+	"cottage":   func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawCottage(x, y) },
+	"fence":     func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawFence(x, y) },
+	"wheat":     func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawWheatField(x, y, t) },
+	"flower":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawFlower(x, y, c) },
+
+	// Wizard's library
+	"library_window": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawLibraryWindow(x, y, t) },
+	"chandelier":     func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawChandelier(x, y, t) },
+	"bookshelf":      func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawTallBookshelf(x, y, t) },
+	"wizard_desk":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawWizardDesk(x, y, t) },
+	"library_rug":    func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) { r.drawLibraryRug(x, y) },
+	"dust": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.drawLibraryDust(r.scrollOffset, t)
+	},
+	"orbs": func(r *Renderer, p *BiomeProp, x, y int32, t float32, c rl.Color) {
+		r.drawFloatingOrbs(r.scrollOffset, t)
+	},
+}
+
+// getBiomeDirs returns where biome packs are read from, built-ins first
+func getBiomeDirs() []string {
+	home, _ := os.UserHomeDir()
+	return []string{getAssetPath("biomes"), filepath.Join(home, ".claude-quest", "biomes")}
+}
+
+// LoadBiomePacks reads every pack in dirs, sorted into cycle order. Broken
+// packs are reported and skipped.
+func LoadBiomePacks(dirs ...string) []*BiomePack {
+	byID := make(map[string]*BiomePack)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+				continue
+			}
+			pack, err := loadBiomePack(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				fmt.Printf("Skipping biome %s: %v\n", entry.Name(), err)
+				continue
+			}
+			byID[pack.ID] = pack
+		}
+	}
+
+	packs := make([]*BiomePack, 0, len(byID))
+	for _, pack := range byID {
+		packs = append(packs, pack)
+	}
+	sort.Slice(packs, func(i, j int) bool {
+		oi, oj := packs[i].Order, packs[j].Order
+		if oi == 0 {
+			oi = math.MaxInt
+		}
+		if oj == 0 {
+			oj = math.MaxInt
+		}
+		if oi != oj {
+			return oi < oj
+		}
+		return packs[i].ID < packs[j].ID
+	})
+	return packs
+}
+
+// loadBiomePack reads and checks one pack file
+func loadBiomePack(path string) (*BiomePack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pack := &BiomePack{}
+	if err := json.Unmarshal(data, pack); err != nil {
+		return nil, err
+	}
+
+	pack.ID = strings.TrimSuffix(filepath.Base(path), ".json")
+	if pack.Name == "" {
+		pack.Name = pack.ID
+	}
+	if pack.Sky.Height <= 0 {
+		pack.Sky.Height = biomeSkyHeight
+	}
+	for i := range pack.Layers {
+		layer := &pack.Layers[i]
+		if layer.Repeat < 0 || layer.Cycle < 0 {
+			return nil, fmt.Errorf("layer %d: repeat and cycle can't be negative", i)
+		}
+		if layer.Image != "" && !filepath.IsAbs(layer.Image) {
+			layer.Image = filepath.Join(filepath.Dir(path), layer.Image)
+		}
+		for _, prop := range layer.Props {
+			if biomePainters[prop.Kind] == nil {
+				return nil, fmt.Errorf("layer %d: unknown prop kind %q", i, prop.Kind)
+			}
+		}
+	}
+	return pack, nil
+}
+
+// loadBiomes loads the biome packs and their image strips
+func (r *Renderer) loadBiomes() {
+	r.biomes = LoadBiomePacks(getBiomeDirs()...)
+	if len(r.biomes) == 0 {
+		fmt.Println("No biome packs found, using a plain sky")
+		r.biomes = []*BiomePack{{
+			ID:   "plain",
+			Name: "Plain",
+			Sky:  BiomeSky{Top: BiomeColor{R: 40, G: 50, B: 80, A: 255}, Bottom: BiomeColor{R: 100, G: 100, B: 110, A: 255}, Height: biomeSkyHeight},
+			Layers: []BiomeLayer{{Props: []BiomeProp{
+				{Kind: "rect", Y: 160, W: screenWidth, H: 40, Color: BiomeColor{R: 60, G: 55, B: 50, A: 255}},
+			}}},
+		}}
+	}
+
+	for _, pack := range r.biomes {
+		for i := range pack.Layers {
+			layer := &pack.Layers[i]
+			if layer.Image == "" {
+				continue
+			}
+			if _, err := os.Stat(layer.Image); err != nil {
+				fmt.Printf("Biome %s: missing image %s\n", pack.ID, layer.Image)
+				continue
+			}
+			layer.texture = r.canvas.LoadTexture(layer.Image)
+		}
+	}
+	fmt.Printf("Loaded %d biomes\n", len(r.biomes))
+}
+
+// BiomeNames returns the display names of the loaded biomes, in cycle order
+func (r *Renderer) BiomeNames() []string {
+	names := make([]string, len(r.biomes))
+	for i, pack := range r.biomes {
+		names[i] = pack.Name
+	}
+	return names
+}
+
+// drawBiome draws a biome pack's sky and layers at the current scroll position.
+// The time-of-day and season overlays go on top once, after any crossfade.
+func (r *Renderer) drawBiome(pack *BiomePack) {
+	sky := pack.Sky
+	for y := int32(0); y < sky.Height; y++ {
+		t := float32(y) / float32(sky.Height)
+		c := rl.Color{
+			R: uint8(float32(sky.Top.R) + t*float32(int(sky.Bottom.R)-int(sky.Top.R))),
+			G: uint8(float32(sky.Top.G) + t*float32(int(sky.Bottom.G)-int(sky.Top.G))),
+			B: uint8(float32(sky.Top.B) + t*float32(int(sky.Bottom.B)-int(sky.Top.B))),
+			A: 255,
+		}
+		if !pack.Indoor {
+			c = r.tintSky(c, t)
+		}
+		r.canvas.DrawLine(0, y, screenWidth, y, c)
+	}
+	if !pack.Indoor {
+		r.drawNightSky(sky.Height)
+	}
+
+	for i := range pack.Layers {
+		r.drawBiomeLayer(&pack.Layers[i])
+	}
+}
+
+// drawBiomeLayer draws one layer's image strip and props
+func (r *Renderer) drawBiomeLayer(layer *BiomeLayer) {
+	offset := int32(r.scrollOffset * layer.Scroll)
+
+	if layer.texture.ID != 0 {
+		w, h := layer.texture.Width, layer.texture.Height
+		src := rl.Rectangle{Width: float32(w), Height: float32(h)}
+		for x := -(offset % w); x < screenWidth; x += w {
+			dst := rl.Rectangle{X: float32(x), Y: float32(layer.Y), Width: float32(w), Height: float32(h)}
+			r.canvas.DrawTexturePro(layer.texture, src, dst, rl.Vector2{}, 0, rl.White)
+		}
+	}
+
+	if layer.Repeat > 0 {
+		start := -layer.Repeat
+		if layer.Start != nil {
+			start = *layer.Start
+		}
+		for base := start; base < screenWidth+layer.Repeat; base += layer.Repeat {
+			x := base - offset%layer.Repeat
+			for i := range layer.Props {
+				p := &layer.Props[i]
+				if p.Every > 1 && base%(layer.Repeat*p.Every) != 0 {
+					continue
+				}
+				r.drawBiomeProp(p, x+p.X, p.Y, base/layer.Repeat)
+			}
+		}
+		return
+	}
+
+	for i := range layer.Props {
+		p := &layer.Props[i]
+		x := p.X - offset
+		if layer.Cycle > 0 {
+			x = p.X - offset%layer.Cycle
+		} else if layer.Wrap {
+			x = (x%screenWidth + screenWidth) % screenWidth
+		}
+		r.drawBiomeProp(p, x, p.Y, 0)
+	}
+}
+
+// drawBiomeProp draws a prop with its color for this tile and moment
+func (r *Renderer) drawBiomeProp(p *BiomeProp, x, y, tile int32) {
+	t := r.biomeTimer + p.Phase
+
+	c := rl.Color(p.Color)
+	if n := int32(len(p.Colors)); n > 0 {
+		c = rl.Color(p.Colors[(tile%n+n)%n])
+	}
+	if p.Pulse != nil {
+		k := (simpleSinF(float64(r.biomeTimer*p.Pulse.Speed+p.Pulse.Phase)) + 1) / 2
+		to := p.Pulse.To
+		c = rl.Color{
+			R: uint8(float64(c.R) + (float64(to.R)-float64(c.R))*k),
+			G: uint8(float64(c.G) + (float64(to.G)-float64(c.G))*k),
+			B: uint8(float64(c.B) + (float64(to.B)-float64(c.B))*k),
+			A: uint8(float64(c.A) + (float64(to.A)-float64(c.A))*k),
+		}
+	}
+
+	biomePainters[p.Kind](r, p, x, y, t, c)
+}
+
+// drawMotes floats a number of glowing specks around an area, for packs that
+// want ambient particles without a dedicated painter
+func (r *Renderer) drawMotes(p *BiomeProp, x, y int32, t float32, c rl.Color) {
+	w, h := max(p.W, 1), max(p.H, 1)
+	for i := 0; i < p.Count; i++ {
+		fx := float64(x) + float64(int32(i*73+17)%w) + 8*simpleSinF(float64(t)*0.8+float64(i)*1.5)
+		fy := float64(y) + float64(int32(i*31+7)%h) + 4*simpleSinF(float64(t)*1.2+float64(i)*0.7)
+		glow := c
+		glow.A = uint8(float64(c.A) * (0.6 + 0.4*simpleSinF(float64(t)*2+float64(i))))
+		r.canvas.DrawPixel(int32(fx), int32(fy), glow)
+	}
+}
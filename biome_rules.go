@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// BIOME RULES
+// ============================================================================
+//
+// After walking through a biome for the dwell time, the next one is chosen by
+// the rules in the config: every rule whose conditions hold is a candidate,
+// picked at random by weight. With no matching rule the biomes cycle in order,
+// and a pinned background never changes. Changes crossfade from the old biome
+// to the new one.
+
+const (
+	defaultBiomeDwell  = 20  // Seconds
+	defaultBiomeFade   = 1.5 // Seconds
+	biomeActivityCalls = 20  // Recent tool calls that decide the dominant activity
+)
+
+// BiomeRule picks a biome when all of its conditions hold
+type BiomeRule struct {
+	Biome    string  `json:"biome"`              // Pack file name or display name
+	Project  string  `json:"project,omitempty"`  // Part of the project path
+	Activity string  `json:"activity,omitempty"` // "reading", "writing", "bash" or "thinking"
+	Hours    []int   `json:"hours,omitempty"`    // [from, to) in local time, e.g. [20, 6] for nights
+	Weight   float64 `json:"weight,omitempty"`   // Odds against other matching rules (default 1)
+}
+
+// matches reports whether the rule applies right now
+func (rule *BiomeRule) matches(project, activity string, hour int) bool {
+	if rule.Project != "" && !strings.Contains(strings.ToLower(project), strings.ToLower(rule.Project)) {
+		return false
+	}
+	if rule.Activity != "" && !strings.EqualFold(rule.Activity, activity) {
+		return false
+	}
+	if len(rule.Hours) == 2 {
+		from, to := rule.Hours[0], rule.Hours[1]
+		if from <= to && (hour < from || hour >= to) {
+			return false
+		}
+		if from > to && hour < from && hour >= to {
+			return false
+		}
+	}
+	return true
+}
+
+// findBiome returns the index of a biome by file name or display name, or -1
+func (r *Renderer) findBiome(name string) int {
+	for i, pack := range r.biomes {
+		if strings.EqualFold(pack.ID, name) || strings.EqualFold(pack.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyBiomeConfig starts in the pinned biome and warns about rules that
+// name biomes that aren't loaded
+func (r *Renderer) applyBiomeConfig() {
+	r.pinnedBiome = -1
+	r.fadeFromBiome = -1
+	switch bg := r.config.Background; bg {
+	case "", "auto", "study":
+		// "study" was the shipped default before biomes could be pinned
+	default:
+		if r.pinnedBiome = r.findBiome(bg); r.pinnedBiome >= 0 {
+			r.currentBiome = r.pinnedBiome
+		} else {
+			fmt.Printf("Unknown background %q, following the biome rules\n", bg)
+		}
+	}
+	for _, rule := range r.config.BiomeRules {
+		if r.findBiome(rule.Biome) < 0 {
+			fmt.Printf("Biome rule names unknown biome %q\n", rule.Biome)
+		}
+	}
+}
+
+// trackBiomeActivity keeps track of the project and what Claude has been
+// doing, for the biome rules
+func (r *Renderer) trackBiomeActivity(event Event) {
+	if event.Cwd != "" {
+		r.biomeProject = event.Cwd
+	}
+
+	activity := ""
+	switch event.Type {
+	case EventReading:
+		activity = "reading"
+	case EventWriting:
+		activity = "writing"
+	case EventBash:
+		activity = "bash"
+	case EventThinking, EventThinkHard:
+		activity = "thinking"
+	default:
+		return
+	}
+	r.biomeActivity = append(r.biomeActivity, activity)
+	if len(r.biomeActivity) > biomeActivityCalls {
+		r.biomeActivity = r.biomeActivity[1:]
+	}
+}
+
+// dominantActivity returns what most of the recent tool calls were, or
+// "" for a mixed stretch
+func (r *Renderer) dominantActivity() string {
+	counts := make(map[string]int)
+	for _, activity := range r.biomeActivity {
+		counts[activity]++
+	}
+	for activity, n := range counts {
+		if n*2 > len(r.biomeActivity) {
+			return activity
+		}
+	}
+	return ""
+}
+
+// updateBiome moves on to the next biome once the dwell time is up
+func (r *Renderer) updateBiome(dt float32) {
+	r.biomeTimer += dt
+	if r.fadeFromBiome >= 0 {
+		r.fadeFromTimer += dt
+	}
+
+	dwell := float32(defaultBiomeDwell)
+	if r.config.BiomeDwell > 0 {
+		dwell = float32(r.config.BiomeDwell)
+	}
+	if r.biomeTimer < dwell || r.pinnedBiome >= 0 {
+		return
+	}
+
+	next := r.chooseBiome()
+	if next == r.currentBiome {
+		r.biomeTimer = 0
+		return
+	}
+	r.fadeFromBiome = r.currentBiome
+	r.fadeFromTimer = r.biomeTimer
+	r.biomeFade = 0
+	r.currentBiome = next
+	r.biomeTimer = 0
+}
+
+// chooseBiome picks the next biome from the matching rules, or the next one
+// in order when none match
+func (r *Renderer) chooseBiome() int {
+	project, activity, hour := r.biomeProject, r.dominantActivity(), r.clock().Hour()
+
+	var candidates []int
+	var weights []float64
+	total := 0.0
+	for i := range r.config.BiomeRules {
+		rule := &r.config.BiomeRules[i]
+		index := r.findBiome(rule.Biome)
+		if index < 0 || !rule.matches(project, activity, hour) {
+			continue
+		}
+		weight := rule.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		candidates = append(candidates, index)
+		weights = append(weights, weight)
+		total += weight
+	}
+
+	if len(candidates) == 0 {
+		return (r.currentBiome + 1) % len(r.biomes)
+	}
+	pick := r.rng.Float64() * total
+	for i, weight := range weights {
+		if pick < weight {
+			return candidates[i]
+		}
+		pick -= weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+// drawBiomeCrossfade draws the biome being left behind, then the current one
+// on top of it, fading in
+func (r *Renderer) drawBiomeCrossfade() {
+	fade := float32(defaultBiomeFade)
+	if r.config.BiomeFade > 0 {
+		fade = float32(r.config.BiomeFade)
+	}
+	r.biomeFade += renderFrameTime()
+	if r.biomeFade >= fade {
+		r.fadeFromBiome = -1
+		r.drawBiome(r.biomes[r.currentBiome])
+		return
+	}
+
+	timer := r.biomeTimer
+	r.biomeTimer = r.fadeFromTimer
+	r.drawBiome(r.biomes[r.fadeFromBiome])
+	r.biomeTimer = timer
+
+	canvas := r.canvas
+	r.canvas = fadedCanvas{Canvas: canvas, alpha: r.biomeFade / fade}
+	r.drawBiome(r.biomes[r.currentBiome])
+	r.canvas = canvas
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ============================================================================
+// TIME OF DAY AND SEASONS
+// ============================================================================
+//
+// Biomes follow the local clock. The sky is tinted for dawn, dusk and night,
+// the scene darkens after sunset, and stars and fireflies come out at night.
+// The date adds a season on top: snow in winter, falling leaves in autumn and
+// a Halloween palette in the last week of October. Indoor biomes only get the
+// lighting.
+
+// skyLight is how the scene looks at one moment
+type skyLight struct {
+	top, bottom rl.Color // Blended into the sky gradient...
+	mix         float32  // ...this much
+	shade       rl.Color // Drawn over the whole background
+	night       float32  // 0 in daylight, 1 at full night
+}
+
+// skyKey is the light at an hour of the day; hours in between blend
+type skyKey struct {
+	hour  float32
+	light skyLight
+}
+
+var (
+	nightLight = skyLight{top: rl.Color{R: 5, G: 8, B: 25, A: 255}, bottom: rl.Color{R: 25, G: 30, B: 60, A: 255}, mix: 0.75, shade: rl.Color{R: 10, G: 15, B: 45, A: 100}, night: 1}
+	dawnLight  = skyLight{top: rl.Color{R: 90, G: 80, B: 140, A: 255}, bottom: rl.Color{R: 255, G: 170, B: 140, A: 255}, mix: 0.35, shade: rl.Color{R: 255, G: 150, B: 120, A: 25}, night: 0.2}
+	dayLight   = skyLight{top: rl.Color{R: 90, G: 80, B: 140, A: 255}, bottom: rl.Color{R: 255, G: 170, B: 140, A: 255}, shade: rl.Color{R: 255, G: 150, B: 120}}
+	duskLight  = skyLight{top: rl.Color{R: 70, G: 50, B: 110, A: 255}, bottom: rl.Color{R: 255, G: 120, B: 70, A: 255}, mix: 0.45, shade: rl.Color{R: 120, G: 50, B: 80, A: 40}, night: 0.3}
+)
+
+var skyKeys = []skyKey{
+	{0, nightLight},
+	{5, nightLight},
+	{6.5, dawnLight},
+	{8, dayLight},
+	{17.5, dayLight},
+	{19, duskLight},
+	{20.5, nightLight},
+	{24, nightLight},
+}
+
+// season is what the date adds to the scene
+type season int
+
+const (
+	seasonNone season = iota
+	seasonWinter
+	seasonAutumn
+	seasonHalloween
+)
+
+// seasonAt returns the season for a date (northern hemisphere)
+func seasonAt(t time.Time) season {
+	switch month := t.Month(); {
+	case month == time.October && t.Day() >= 24:
+		return seasonHalloween
+	case month == time.December || month <= time.February:
+		return seasonWinter
+	case month >= time.September && month <= time.November:
+		return seasonAutumn
+	}
+	return seasonNone
+}
+
+// lightAt returns the light for a moment, seasons included
+func lightAt(t time.Time) skyLight {
+	hour := float32(t.Hour()) + float32(t.Minute())/60
+	light := nightLight
+	for i := 1; i < len(skyKeys); i++ {
+		if hour < skyKeys[i].hour {
+			a, b := skyKeys[i-1], skyKeys[i]
+			light = blendLight(a.light, b.light, (hour-a.hour)/(b.hour-a.hour))
+			break
+		}
+	}
+
+	switch seasonAt(t) {
+	case seasonWinter:
+		// A pale, cold sky by day that gives way to the night tint
+		day := 1 - light.mix
+		light.top = lerpColor(light.top, rl.Color{R: 150, G: 170, B: 200, A: 255}, day)
+		light.bottom = lerpColor(light.bottom, rl.Color{R: 220, G: 230, B: 240, A: 255}, day)
+		light.mix += 0.2 * day
+	case seasonHalloween:
+		light.top = lerpColor(light.top, rl.Color{R: 40, G: 15, B: 60, A: 255}, 0.6)
+		light.bottom = lerpColor(light.bottom, rl.Color{R: 230, G: 110, B: 30, A: 255}, 0.6)
+		light.mix = 0.5 + light.mix/2
+		light.shade = lerpColor(light.shade, rl.Color{R: 120, G: 40, B: 20, A: 50}, 0.5)
+	}
+	return light
+}
+
+// blendLight mixes two lights, k of the way from a to b
+func blendLight(a, b skyLight, k float32) skyLight {
+	return skyLight{
+		top:    lerpColor(a.top, b.top, k),
+		bottom: lerpColor(a.bottom, b.bottom, k),
+		mix:    a.mix + (b.mix-a.mix)*k,
+		shade:  lerpColor(a.shade, b.shade, k),
+		night:  a.night + (b.night-a.night)*k,
+	}
+}
+
+// lerpColor mixes two colors, alpha included
+func lerpColor(a, b rl.Color, k float32) rl.Color {
+	return rl.Color{
+		R: uint8(float32(a.R) + (float32(b.R)-float32(a.R))*k),
+		G: uint8(float32(a.G) + (float32(b.G)-float32(a.G))*k),
+		B: uint8(float32(a.B) + (float32(b.B)-float32(a.B))*k),
+		A: uint8(float32(a.A) + (float32(b.A)-float32(a.A))*k),
+	}
+}
+
+// SetClock replaces the wall clock the sky follows (replays use the
+// session's own timestamps)
+func (r *Renderer) SetClock(clock func() time.Time) {
+	r.clock = clock
+}
+
+// tintSky applies the time of day to a sky line, t of the way down the sky
+func (r *Renderer) tintSky(c rl.Color, t float32) rl.Color {
+	if r.sky.mix <= 0 {
+		return c
+	}
+	return lerpColor(c, lerpColor(r.sky.top, r.sky.bottom, t), r.sky.mix)
+}
+
+// drawNightSky scatters stars over the sky after dark
+func (r *Renderer) drawNightSky(skyHeight int32) {
+	if r.sky.night <= 0 {
+		return
+	}
+	scroll := int32(r.scrollOffset * 0.02)
+	for i := 0; i < 24; i++ {
+		x := (int32(i*89+31)-scroll)%screenWidth + screenWidth
+		y := int32(i*37+11) % (skyHeight * 3 / 5)
+		twinkle := 0.6 + 0.4*simpleSinF(renderTime()*1.5+float64(i)*1.3)
+		alpha := uint8(255 * r.sky.night * float32(twinkle))
+		r.canvas.DrawPixel(x%screenWidth, y, rl.Color{R: 230, G: 230, B: 255, A: alpha})
+	}
+}
+
+// drawSkyShade darkens or warms the whole background for the time of day
+func (r *Renderer) drawSkyShade() {
+	if r.sky.shade.A > 0 {
+		r.canvas.DrawRectangle(0, 0, screenWidth, screenHeight, r.sky.shade)
+	}
+}
+
+// drawNightFireflies lets fireflies out once it gets dark
+func (r *Renderer) drawNightFireflies() {
+	if r.sky.night < 0.5 {
+		return
+	}
+	canvas := r.canvas
+	r.canvas = fadedCanvas{Canvas: canvas, alpha: (r.sky.night - 0.5) * 2}
+	r.drawFireflies(r.scrollOffset, float32(renderTime()))
+	r.canvas = canvas
+}
+
+// drawSeason drifts snow or leaves across the scene
+func (r *Renderer) drawSeason() {
+	time := renderTime()
+	switch r.season {
+	case seasonWinter:
+		for i := 0; i < 40; i++ {
+			fall := 12 + float64(i%5)*3
+			y := int32(float64(i*53)+time*fall) % screenHeight
+			x := int32(float64(i*71)+12*simpleSinF(time*0.7+float64(i))-float64(r.scrollOffset)*0.3) % screenWidth
+			if x < 0 {
+				x += screenWidth
+			}
+			flake := rl.Color{R: 240, G: 245, B: 255, A: uint8(160 + (i%3)*40)}
+			r.canvas.DrawPixel(x, y, flake)
+			if i%4 == 0 {
+				r.canvas.DrawPixel(x+1, y, flake)
+			}
+		}
+	case seasonAutumn, seasonHalloween:
+		leaves := []rl.Color{
+			{R: 200, G: 90, B: 30, A: 230},
+			{R: 170, G: 50, B: 30, A: 230},
+			{R: 210, G: 150, B: 40, A: 230},
+			{R: 120, G: 70, B: 40, A: 230},
+		}
+		for i := 0; i < 14; i++ {
+			fall := 10 + float64(i%4)*4
+			y := int32(float64(i*67)+time*fall) % screenHeight
+			sway := simpleSinF(time*1.5 + float64(i)*2)
+			x := int32(float64(i*97)+18*sway-float64(r.scrollOffset)*0.5) % screenWidth
+			if x < 0 {
+				x += screenWidth
+			}
+			leaf := leaves[i%len(leaves)]
+			r.canvas.DrawPixel(x, y, leaf)
+			// Leaves tumble: flat, then on edge
+			if sway > 0 {
+				r.canvas.DrawPixel(x+1, y, leaf)
+			} else {
+				r.canvas.DrawPixel(x, y+1, leaf)
+			}
+		}
+	}
+}
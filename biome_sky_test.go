@@ -0,0 +1,62 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSeasonAt(t *testing.T) {
+	tests := []struct {
+		month time.Month
+		day   int
+		want  season
+	}{
+		{time.January, 10, seasonWinter},
+		{time.February, 28, seasonWinter},
+		{time.March, 1, seasonNone},
+		{time.June, 15, seasonNone},
+		{time.September, 1, seasonAutumn},
+		{time.October, 23, seasonAutumn},
+		{time.October, 24, seasonHalloween},
+		{time.October, 31, seasonHalloween},
+		{time.November, 30, seasonAutumn},
+		{time.December, 1, seasonWinter},
+	}
+	for _, tt := range tests {
+		date := time.Date(2025, tt.month, tt.day, 12, 0, 0, 0, time.Local)
+		if got := seasonAt(date); got != tt.want {
+			t.Errorf("seasonAt(%s %d) = %d, want %d", tt.month, tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestLightAt(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		night        float32
+		mix          float32
+	}{
+		{2, 0, 1, 0.75},
+		{5, 45, 0.6, 0.55}, // Halfway from night to dawn
+		{6, 30, 0.2, 0.35},
+		{12, 0, 0, 0},
+		{19, 0, 0.3, 0.45},
+		{23, 0, 1, 0.75},
+	}
+	for _, tt := range tests {
+		light := lightAt(time.Date(2025, time.June, 15, tt.hour, tt.minute, 0, 0, time.Local))
+		if math.Abs(float64(light.night-tt.night)) > 0.01 || math.Abs(float64(light.mix-tt.mix)) > 0.01 {
+			t.Errorf("%02d:%02d: night %.2f and mix %.2f, want %.2f and %.2f",
+				tt.hour, tt.minute, light.night, light.mix, tt.night, tt.mix)
+		}
+	}
+
+	// Seasons tint the daylight sky
+	if light := lightAt(time.Date(2025, time.January, 15, 12, 0, 0, 0, time.Local)); math.Abs(float64(light.mix-0.2)) > 0.01 {
+		t.Errorf("winter noon mix %.2f, want 0.2", light.mix)
+	}
+	if light := lightAt(time.Date(2025, time.October, 31, 12, 0, 0, 0, time.Local)); math.Abs(float64(light.mix-0.5)) > 0.01 {
+		t.Errorf("Halloween noon mix %.2f, want 0.5", light.mix)
+	}
+}
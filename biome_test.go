@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadBiomePacks(t *testing.T) {
+	user := t.TempDir()
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(user, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("kingdom.json", `{"name": "My Kingdom", "order": 4, "sky": {"top": [0, 0, 0], "bottom": [10, 10, 10]}}`)
+	write("meadow.json", `{"name": "Meadow", "layers": [{"scroll": 1, "props": [{"kind": "motes", "count": 5}]}]}`)
+	write("broken.json", `{"layers": [{"props": [{"kind": "dragon"}]}]}`)
+	write("badcolor.json", `{"sky": {"top": [300, 0, 0]}}`)
+
+	packs := LoadBiomePacks(getAssetPath("biomes"), user)
+
+	var names []string
+	for _, pack := range packs {
+		names = append(names, pack.Name)
+	}
+	want := []string{"Forest", "Mountain", "Midnight", "My Kingdom", "Library", "Meadow"}
+	if len(names) != len(want) {
+		t.Fatalf("got biomes %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got biomes %v, want %v", names, want)
+		}
+	}
+
+	meadow := packs[5]
+	if meadow.Sky.Height != biomeSkyHeight {
+		t.Errorf("sky height defaulted to %d, want %d", meadow.Sky.Height, biomeSkyHeight)
+	}
+	if packs[0].Sky.Top != (BiomeColor{R: 15, G: 25, B: 35, A: 255}) {
+		t.Errorf("forest sky top is %v", packs[0].Sky.Top)
+	}
+}
+
+func TestBiomeRuleMatches(t *testing.T) {
+	tests := []struct {
+		rule     BiomeRule
+		project  string
+		activity string
+		hour     int
+		want     bool
+	}{
+		{BiomeRule{Biome: "forest"}, "", "", 12, true},
+		{BiomeRule{Project: "claude-quest"}, "/home/me/Claude-Quest", "", 12, true},
+		{BiomeRule{Project: "claude-quest"}, "/home/me/other", "", 12, false},
+		{BiomeRule{Activity: "reading"}, "", "reading", 12, true},
+		{BiomeRule{Activity: "reading"}, "", "", 12, false},
+		{BiomeRule{Hours: []int{9, 17}}, "", "", 16, true},
+		{BiomeRule{Hours: []int{9, 17}}, "", "", 17, false},
+		{BiomeRule{Hours: []int{20, 6}}, "", "", 23, true},
+		{BiomeRule{Hours: []int{20, 6}}, "", "", 3, true},
+		{BiomeRule{Hours: []int{20, 6}}, "", "", 12, false},
+		{BiomeRule{Activity: "bash", Hours: []int{20, 6}}, "", "reading", 23, false},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.matches(tt.project, tt.activity, tt.hour); got != tt.want {
+			t.Errorf("%+v matches(%q, %q, %d) = %v, want %v", tt.rule, tt.project, tt.activity, tt.hour, got, tt.want)
+		}
+	}
+}
+
+func TestDominantActivity(t *testing.T) {
+	r := &Renderer{}
+	for i := 0; i < 3; i++ {
+		r.HandleEvent(Event{Type: EventReading})
+	}
+	r.HandleEvent(Event{Type: EventBash})
+	r.HandleEvent(Event{Type: EventQuest}) // Not a tool call
+	if got := r.dominantActivity(); got != "reading" {
+		t.Errorf("dominant activity is %q, want reading", got)
+	}
+
+	r.HandleEvent(Event{Type: EventBash})
+	r.HandleEvent(Event{Type: EventBash})
+	if got := r.dominantActivity(); got != "" {
+		t.Errorf("dominant activity of a mixed stretch is %q, want none", got)
+	}
+}
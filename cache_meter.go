@@ -0,0 +1,47 @@
+package main
+
+// cacheHistoryLen is how many turns the cache sparkline remembers
+const cacheHistoryLen = 32
+
+// CacheHitRatio returns the share of input tokens served from the prompt cache
+func (t TokenTotals) CacheHitRatio() float32 {
+	input := t.Input + t.CacheCreation + t.CacheRead
+	if input == 0 {
+		return 0
+	}
+	return float32(t.CacheRead) / float32(input)
+}
+
+// CacheMeter tracks prompt cache hits per turn for the cache rune
+type CacheMeter struct {
+	Turn    float32   // Hit ratio of the latest turn
+	History []float32 // Recent per-turn hit ratios, oldest first
+	Glow    float32   // Rune brightness, eases toward Turn
+	Turns   int       // Turns recorded this session
+}
+
+// Record adds a turn's usage to the meter
+func (c *CacheMeter) Record(u *TokenUsage) {
+	t := TokenTotals{
+		Input:         int64(u.InputTokens),
+		CacheCreation: int64(u.CacheCreationTokens),
+		CacheRead:     int64(u.CacheReadTokens),
+	}
+	c.Turn = t.CacheHitRatio()
+	c.Turns++
+
+	c.History = append(c.History, c.Turn)
+	if len(c.History) > cacheHistoryLen {
+		c.History = c.History[len(c.History)-cacheHistoryLen:]
+	}
+}
+
+// Rebuilding returns true when the latest turn mostly rewrote the cache
+func (c *CacheMeter) Rebuilding() bool {
+	return c.Turns > 0 && c.Turn < 0.5
+}
+
+// Update eases the rune glow toward the latest hit ratio
+func (c *CacheMeter) Update(dt float32) {
+	c.Glow += (c.Turn - c.Glow) * dt * 2
+}
@@ -0,0 +1,131 @@
+package main
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Canvas is what the renderer draws the scene on. The window draws through
+// raylib; modes without a GL context (cq tui, cq render) use a software raster.
+// Textures are raylib texture handles either way, so the renderer's texture
+// fields work with both.
+type Canvas interface {
+	LoadTexture(path string) rl.Texture2D
+	UnloadTexture(tex rl.Texture2D)
+	DrawTexturePro(tex rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, tint rl.Color)
+
+	DrawRectangle(x, y, width, height int32, col rl.Color)
+	DrawRectangleLines(x, y, width, height int32, col rl.Color)
+	DrawRectangleRounded(rec rl.Rectangle, roundness float32, segments int32, col rl.Color)
+	DrawRectangleRoundedLines(rec rl.Rectangle, roundness float32, segments int32, col rl.Color)
+	DrawPixel(x, y int32, col rl.Color)
+	DrawLine(x1, y1, x2, y2 int32, col rl.Color)
+	DrawCircle(cx, cy int32, radius float32, col rl.Color)
+	DrawCircleLines(cx, cy int32, radius float32, col rl.Color)
+	DrawEllipse(cx, cy int32, rx, ry float32, col rl.Color)
+
+	DrawText(text string, x, y, size int32, col rl.Color)
+	MeasureText(text string, size int32) int32
+}
+
+// sceneBackground is the color behind the scene, in every canvas
+var sceneBackground = rl.Color{R: 24, G: 20, B: 37, A: 255} // Dark purple
+
+// raylibCanvas draws on the current raylib target
+type raylibCanvas struct{}
+
+func (raylibCanvas) LoadTexture(path string) rl.Texture2D { return rl.LoadTexture(path) }
+func (raylibCanvas) UnloadTexture(tex rl.Texture2D)       { rl.UnloadTexture(tex) }
+
+func (raylibCanvas) DrawTexturePro(tex rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, tint rl.Color) {
+	rl.DrawTexturePro(tex, src, dst, origin, rotation, tint)
+}
+
+func (raylibCanvas) DrawRectangle(x, y, width, height int32, col rl.Color) {
+	rl.DrawRectangle(x, y, width, height, col)
+}
+
+func (raylibCanvas) DrawRectangleLines(x, y, width, height int32, col rl.Color) {
+	rl.DrawRectangleLines(x, y, width, height, col)
+}
+
+func (raylibCanvas) DrawRectangleRounded(rec rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	rl.DrawRectangleRounded(rec, roundness, segments, col)
+}
+
+func (raylibCanvas) DrawRectangleRoundedLines(rec rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	rl.DrawRectangleRoundedLines(rec, roundness, segments, col)
+}
+
+func (raylibCanvas) DrawPixel(x, y int32, col rl.Color) { rl.DrawPixel(x, y, col) }
+
+func (raylibCanvas) DrawLine(x1, y1, x2, y2 int32, col rl.Color) { rl.DrawLine(x1, y1, x2, y2, col) }
+
+func (raylibCanvas) DrawCircle(cx, cy int32, radius float32, col rl.Color) {
+	rl.DrawCircle(cx, cy, radius, col)
+}
+
+func (raylibCanvas) DrawCircleLines(cx, cy int32, radius float32, col rl.Color) {
+	rl.DrawCircleLines(cx, cy, radius, col)
+}
+
+func (raylibCanvas) DrawEllipse(cx, cy int32, rx, ry float32, col rl.Color) {
+	rl.DrawEllipse(cx, cy, rx, ry, col)
+}
+
+func (raylibCanvas) DrawText(text string, x, y, size int32, col rl.Color) {
+	rl.DrawText(text, x, y, size, col)
+}
+
+func (raylibCanvas) MeasureText(text string, size int32) int32 { return rl.MeasureText(text, size) }
+
+// fadedCanvas draws on another canvas with every color made more see-through,
+// for fading a whole scene in over another
+type fadedCanvas struct {
+	Canvas
+	alpha float32 // 0 = invisible, 1 = unchanged
+}
+
+func (c fadedCanvas) fade(col rl.Color) rl.Color {
+	col.A = uint8(float32(col.A) * c.alpha)
+	return col
+}
+
+func (c fadedCanvas) DrawTexturePro(tex rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, tint rl.Color) {
+	c.Canvas.DrawTexturePro(tex, src, dst, origin, rotation, c.fade(tint))
+}
+
+func (c fadedCanvas) DrawRectangle(x, y, width, height int32, col rl.Color) {
+	c.Canvas.DrawRectangle(x, y, width, height, c.fade(col))
+}
+
+func (c fadedCanvas) DrawRectangleLines(x, y, width, height int32, col rl.Color) {
+	c.Canvas.DrawRectangleLines(x, y, width, height, c.fade(col))
+}
+
+func (c fadedCanvas) DrawRectangleRounded(rec rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	c.Canvas.DrawRectangleRounded(rec, roundness, segments, c.fade(col))
+}
+
+func (c fadedCanvas) DrawRectangleRoundedLines(rec rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	c.Canvas.DrawRectangleRoundedLines(rec, roundness, segments, c.fade(col))
+}
+
+func (c fadedCanvas) DrawPixel(x, y int32, col rl.Color) { c.Canvas.DrawPixel(x, y, c.fade(col)) }
+
+func (c fadedCanvas) DrawLine(x1, y1, x2, y2 int32, col rl.Color) {
+	c.Canvas.DrawLine(x1, y1, x2, y2, c.fade(col))
+}
+
+func (c fadedCanvas) DrawCircle(cx, cy int32, radius float32, col rl.Color) {
+	c.Canvas.DrawCircle(cx, cy, radius, c.fade(col))
+}
+
+func (c fadedCanvas) DrawCircleLines(cx, cy int32, radius float32, col rl.Color) {
+	c.Canvas.DrawCircleLines(cx, cy, radius, c.fade(col))
+}
+
+func (c fadedCanvas) DrawEllipse(cx, cy int32, rx, ry float32, col rl.Color) {
+	c.Canvas.DrawEllipse(cx, cy, rx, ry, c.fade(col))
+}
+
+func (c fadedCanvas) DrawText(text string, x, y, size int32, col rl.Color) {
+	c.Canvas.DrawText(text, x, y, size, c.fade(col))
+}
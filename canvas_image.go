@@ -0,0 +1,383 @@
+package main
+
+import (
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ImageCanvas is a software Canvas over an image.RGBA. It needs no GL context,
+// so it works in a terminal, on a server or in tests.
+type ImageCanvas struct {
+	Image *image.RGBA
+
+	textures map[uint32]*image.RGBA // Texture ID -> pixels
+	nextID   uint32
+}
+
+// NewImageCanvas creates a software canvas of the given size
+func NewImageCanvas(width, height int) *ImageCanvas {
+	return &ImageCanvas{
+		Image:    image.NewRGBA(image.Rect(0, 0, width, height)),
+		textures: make(map[uint32]*image.RGBA),
+	}
+}
+
+// Clear fills the whole canvas with a color
+func (c *ImageCanvas) Clear(col rl.Color) {
+	pix := c.Image.Pix
+	for i := 0; i < len(pix); i += 4 {
+		pix[i], pix[i+1], pix[i+2], pix[i+3] = col.R, col.G, col.B, col.A
+	}
+}
+
+// LoadTexture decodes a PNG into memory. A zero texture is returned if it
+// can't be read, like raylib does.
+func (c *ImageCanvas) LoadTexture(path string) rl.Texture2D {
+	file, err := os.Open(path)
+	if err != nil {
+		return rl.Texture2D{}
+	}
+	defer file.Close()
+	src, err := png.Decode(file)
+	if err != nil {
+		return rl.Texture2D{}
+	}
+
+	img := image.NewRGBA(src.Bounds().Sub(src.Bounds().Min))
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	c.nextID++
+	c.textures[c.nextID] = img
+	return rl.Texture2D{ID: c.nextID, Width: int32(img.Rect.Dx()), Height: int32(img.Rect.Dy()), Mipmaps: 1}
+}
+
+// UnloadTexture frees a texture's pixels
+func (c *ImageCanvas) UnloadTexture(tex rl.Texture2D) {
+	delete(c.textures, tex.ID)
+}
+
+// blend draws one pixel with alpha blending, tinted by col
+func (c *ImageCanvas) blend(x, y int, col rl.Color) {
+	if col.A == 0 || !(image.Point{X: x, Y: y}.In(c.Image.Rect)) {
+		return
+	}
+	i := c.Image.PixOffset(x, y)
+	pix := c.Image.Pix[i : i+4 : i+4]
+	if col.A == 255 {
+		pix[0], pix[1], pix[2], pix[3] = col.R, col.G, col.B, 255
+		return
+	}
+	a := uint32(col.A)
+	inv := 255 - a
+	pix[0] = uint8((uint32(col.R)*a + uint32(pix[0])*inv) / 255)
+	pix[1] = uint8((uint32(col.G)*a + uint32(pix[1])*inv) / 255)
+	pix[2] = uint8((uint32(col.B)*a + uint32(pix[2])*inv) / 255)
+	pix[3] = uint8(a + uint32(pix[3])*inv/255)
+}
+
+// fillRect blends a clipped rectangle
+func (c *ImageCanvas) fillRect(x0, y0, x1, y1 int, col rl.Color) {
+	r := image.Rect(x0, y0, x1, y1).Intersect(c.Image.Rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c.blend(x, y, col)
+		}
+	}
+}
+
+// DrawTexturePro draws part of a texture scaled into dst (nearest neighbour,
+// negative source sizes flip). Rotation isn't supported and is ignored.
+func (c *ImageCanvas) DrawTexturePro(tex rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, tint rl.Color) {
+	img := c.textures[tex.ID]
+	if img == nil || dst.Width == 0 || dst.Height == 0 {
+		return
+	}
+	flipX, flipY := src.Width < 0, src.Height < 0
+	srcW, srcH := float32(math.Abs(float64(src.Width))), float32(math.Abs(float64(src.Height)))
+
+	dx0 := int(math.Round(float64(dst.X - origin.X)))
+	dy0 := int(math.Round(float64(dst.Y - origin.Y)))
+	dw := int(math.Round(float64(dst.Width)))
+	dh := int(math.Round(float64(dst.Height)))
+
+	for dy := 0; dy < dh; dy++ {
+		v := (float32(dy) + 0.5) / float32(dh)
+		if flipY {
+			v = 1 - v
+		}
+		sy := int(src.Y + v*srcH)
+		for dx := 0; dx < dw; dx++ {
+			u := (float32(dx) + 0.5) / float32(dw)
+			if flipX {
+				u = 1 - u
+			}
+			sx := int(src.X + u*srcW)
+			if !(image.Point{X: sx, Y: sy}.In(img.Rect)) {
+				continue
+			}
+			p := img.RGBAAt(sx, sy)
+			c.blend(dx0+dx, dy0+dy, rl.Color{
+				R: uint8(uint32(p.R) * uint32(tint.R) / 255),
+				G: uint8(uint32(p.G) * uint32(tint.G) / 255),
+				B: uint8(uint32(p.B) * uint32(tint.B) / 255),
+				A: uint8(uint32(p.A) * uint32(tint.A) / 255),
+			})
+		}
+	}
+}
+
+// DrawRectangle fills a rectangle
+func (c *ImageCanvas) DrawRectangle(x, y, width, height int32, col rl.Color) {
+	c.fillRect(int(x), int(y), int(x+width), int(y+height), col)
+}
+
+// DrawRectangleLines draws a one pixel rectangle outline
+func (c *ImageCanvas) DrawRectangleLines(x, y, width, height int32, col rl.Color) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	x0, y0, x1, y1 := int(x), int(y), int(x+width), int(y+height)
+	c.fillRect(x0, y0, x1, y0+1, col)
+	c.fillRect(x0, y1-1, x1, y1, col)
+	c.fillRect(x0, y0+1, x0+1, y1-1, col)
+	c.fillRect(x1-1, y0+1, x1, y1-1, col)
+}
+
+// DrawRectangleRounded fills a rectangle with rounded corners. Roundness is
+// the corner radius as a fraction of half the shorter side, like raylib.
+func (c *ImageCanvas) DrawRectangleRounded(rec rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	radius := cornerRadius(rec, roundness)
+	c.eachPixelIn(rec, func(x, y int, fx, fy float32) {
+		if insideRounded(fx, fy, rec, radius) {
+			c.blend(x, y, col)
+		}
+	})
+}
+
+// DrawRectangleRoundedLines draws a one pixel outline of a rounded rectangle
+func (c *ImageCanvas) DrawRectangleRoundedLines(rec rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	radius := cornerRadius(rec, roundness)
+	inner := rl.Rectangle{X: rec.X + 1, Y: rec.Y + 1, Width: rec.Width - 2, Height: rec.Height - 2}
+	c.eachPixelIn(rec, func(x, y int, fx, fy float32) {
+		if insideRounded(fx, fy, rec, radius) && !insideRounded(fx, fy, inner, radius-1) {
+			c.blend(x, y, col)
+		}
+	})
+}
+
+// cornerRadius converts raylib's roundness to a radius in pixels
+func cornerRadius(rec rl.Rectangle, roundness float32) float32 {
+	if roundness > 1 {
+		roundness = 1
+	}
+	return roundness * min32(rec.Width, rec.Height) / 2
+}
+
+// eachPixelIn calls fn with every pixel (and its center) that rec touches
+func (c *ImageCanvas) eachPixelIn(rec rl.Rectangle, fn func(x, y int, fx, fy float32)) {
+	for y := int(rec.Y); y <= int(rec.Y+rec.Height); y++ {
+		for x := int(rec.X); x <= int(rec.X+rec.Width); x++ {
+			fn(x, y, float32(x)+0.5, float32(y)+0.5)
+		}
+	}
+}
+
+// insideRounded returns true if a point is inside a rounded rectangle
+func insideRounded(px, py float32, rec rl.Rectangle, radius float32) bool {
+	if rec.Width <= 0 || rec.Height <= 0 || px < rec.X || py < rec.Y || px > rec.X+rec.Width || py > rec.Y+rec.Height {
+		return false
+	}
+	if radius <= 0 {
+		return true
+	}
+	// Distance from the nearest corner circle's center, if in a corner
+	dx := max(rec.X+radius-px, px-(rec.X+rec.Width-radius), 0)
+	dy := max(rec.Y+radius-py, py-(rec.Y+rec.Height-radius), 0)
+	return dx*dx+dy*dy <= radius*radius
+}
+
+// min32 returns the smaller of two float32s
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// DrawPixel sets one pixel
+func (c *ImageCanvas) DrawPixel(x, y int32, col rl.Color) {
+	c.blend(int(x), int(y), col)
+}
+
+// DrawLine draws a one pixel wide line
+func (c *ImageCanvas) DrawLine(x1, y1, x2, y2 int32, col rl.Color) {
+	x, y := int(x1), int(y1)
+	dx, dy := int(x2)-x, int(y2)-y
+	sx, sy := 1, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	if dy > 0 {
+		dy = -dy
+	} else {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.blend(x, y, col)
+		if x == int(x2) && y == int(y2) {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+// DrawCircle fills a circle
+func (c *ImageCanvas) DrawCircle(cx, cy int32, radius float32, col rl.Color) {
+	c.DrawEllipse(cx, cy, radius, radius, col)
+}
+
+// DrawCircleLines draws a one pixel circle outline
+func (c *ImageCanvas) DrawCircleLines(cx, cy int32, radius float32, col rl.Color) {
+	if radius <= 0 {
+		return
+	}
+	inner := radius - 1
+	for y := int(float32(cy)-radius) - 1; y <= int(float32(cy)+radius)+1; y++ {
+		fy := float32(y) + 0.5 - float32(cy)
+		for x := int(float32(cx)-radius) - 1; x <= int(float32(cx)+radius)+1; x++ {
+			fx := float32(x) + 0.5 - float32(cx)
+			d := fx*fx + fy*fy
+			if d <= radius*radius && (inner <= 0 || d > inner*inner) {
+				c.blend(x, y, col)
+			}
+		}
+	}
+}
+
+// DrawEllipse fills an ellipse, covering pixels whose centers fall inside
+func (c *ImageCanvas) DrawEllipse(cx, cy int32, rx, ry float32, col rl.Color) {
+	if rx <= 0 || ry <= 0 {
+		return
+	}
+	x0, x1 := int(float32(cx)-rx)-1, int(float32(cx)+rx)+1
+	y0, y1 := int(float32(cy)-ry)-1, int(float32(cy)+ry)+1
+	for y := y0; y <= y1; y++ {
+		fy := (float32(y) + 0.5 - float32(cy)) / ry
+		for x := x0; x <= x1; x++ {
+			fx := (float32(x) + 0.5 - float32(cx)) / rx
+			if fx*fx+fy*fy <= 1 {
+				c.blend(x, y, col)
+			}
+		}
+	}
+}
+
+// ============================================================================
+// TEXT (raylib's default font)
+// ============================================================================
+
+const (
+	defaultFontAtlas  = 128 // Atlas is 128x128
+	defaultFontHeight = 10  // Glyph height, and the smallest size raylib draws text at
+)
+
+// defaultFontGlyphs holds each glyph's position in the atlas, laid out the
+// way raylib does it
+var defaultFontGlyphs = func() [224]image.Point {
+	var glyphs [224]image.Point
+	x, line := 1, 0
+	for i, w := range defaultFontWidths {
+		glyphs[i] = image.Point{X: x, Y: 1 + line*(defaultFontHeight+1)}
+		next := x + int(w) + 1
+		if next >= defaultFontAtlas {
+			line++
+			glyphs[i] = image.Point{X: 1, Y: 1 + line*(defaultFontHeight+1)}
+			next = 2 + int(w)
+		}
+		x = next
+	}
+	return glyphs
+}()
+
+// glyphIndex maps a rune to the default font (unknown runes draw as '?')
+func glyphIndex(r rune) int {
+	if r < 32 || r >= 32+224 {
+		return '?' - 32
+	}
+	return int(r - 32)
+}
+
+// fontBit returns whether a pixel of the font atlas is set
+func fontBit(x, y int) bool {
+	p := y*defaultFontAtlas + x
+	return defaultFontBits[p/32]&(1<<uint(p%32)) != 0
+}
+
+// textScale returns raylib's glyph scale and spacing for a font size
+func textScale(size int32) (int, int) {
+	if size < defaultFontHeight {
+		size = defaultFontHeight
+	}
+	return int(size) / defaultFontHeight, int(size) / defaultFontHeight
+}
+
+// DrawText draws text with the default font
+func (c *ImageCanvas) DrawText(text string, x, y, size int32, col rl.Color) {
+	scale, spacing := textScale(size)
+	penX, penY := int(x), int(y)
+	for _, r := range text {
+		if r == '\n' {
+			penX = int(x)
+			penY += (defaultFontHeight + 2) * scale
+			continue
+		}
+		i := glyphIndex(r)
+		w := int(defaultFontWidths[i])
+		if r != ' ' && r != '\t' {
+			g := defaultFontGlyphs[i]
+			for gy := 0; gy < defaultFontHeight; gy++ {
+				for gx := 0; gx < w; gx++ {
+					if fontBit(g.X+gx, g.Y+gy) {
+						c.fillRect(penX+gx*scale, penY+gy*scale, penX+(gx+1)*scale, penY+(gy+1)*scale, col)
+					}
+				}
+			}
+		}
+		penX += w*scale + spacing
+	}
+}
+
+// MeasureText returns the width of text drawn with the default font
+func (c *ImageCanvas) MeasureText(text string, size int32) int32 {
+	scale, spacing := textScale(size)
+	widest, width, count, most := 0, 0, 0, 0
+	for _, r := range text {
+		count++
+		if r == '\n' {
+			widest = max(widest, width)
+			width, count = 0, 0
+			continue
+		}
+		width += int(defaultFontWidths[glyphIndex(r)])
+		most = max(most, count)
+	}
+	widest = max(widest, width)
+	if most == 0 {
+		return 0
+	}
+	return int32(widest*scale + (most-1)*spacing)
+}
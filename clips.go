@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ============================================================================
+// HIGHLIGHT CLIPS
+// ============================================================================
+//
+// When clips are on, the last few seconds of frames are kept in memory. A
+// highlight (shipping, a level-up, a flow peak, a long bash streak) records a
+// little longer so the payoff is in frame, then writes a GIF to
+// ~/.claude-quest/clips in the background.
+
+const (
+	clipFPS          = 10
+	clipPostRoll     = 3 * time.Second // Keep recording after the highlight
+	defaultClipLen   = 8 * time.Second // Whole clip length, post-roll included
+	defaultClipsKeep = 20
+	clipBashStreak   = 10 // Bash streak that counts as a highlight
+)
+
+// getClipsDir returns where highlight clips are saved
+func getClipsDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".claude-quest", "clips")
+}
+
+// ClipRecorder keeps a rolling buffer of frames and saves highlight clips
+type ClipRecorder struct {
+	frames   []*image.RGBA // Ring buffer of recent frames
+	next     int           // Ring index the next frame goes to
+	count    int           // Frames in the buffer
+	timer    float32       // Time since the last captured frame
+	keep     int           // Clips to keep on disk
+	reason   string        // Highlight being recorded ("" = none)
+	postRoll float32       // Seconds of post-roll left
+}
+
+// NewClipRecorder creates a recorder, or nil when clips are off
+func NewClipRecorder(config *Config) *ClipRecorder {
+	if !config.Clips {
+		return nil
+	}
+	length := defaultClipLen
+	if config.ClipSeconds > 0 {
+		length = time.Duration(config.ClipSeconds * float64(time.Second))
+	}
+	keep := config.ClipsKeep
+	if keep <= 0 {
+		keep = defaultClipsKeep
+	}
+	return &ClipRecorder{
+		frames: make([]*image.RGBA, int(length.Seconds()*clipFPS)+1),
+		keep:   keep,
+	}
+}
+
+// Trigger starts recording a highlight (ignored while one is being recorded)
+func (c *ClipRecorder) Trigger(reason string) {
+	if c == nil || c.reason != "" {
+		return
+	}
+	c.reason = reason
+	c.postRoll = float32(clipPostRoll.Seconds())
+}
+
+// Update captures a frame from the render target when one is due, and saves
+// the clip once the post-roll is done
+func (c *ClipRecorder) Update(dt float32, target rl.RenderTexture2D) {
+	if c == nil {
+		return
+	}
+	c.timer += dt
+	if c.timer < 1.0/clipFPS {
+		return
+	}
+	c.timer -= 1.0 / clipFPS
+
+	c.frames[c.next] = captureFrame(target, 1)
+	c.next = (c.next + 1) % len(c.frames)
+	if c.count < len(c.frames) {
+		c.count++
+	}
+
+	if c.reason == "" {
+		return
+	}
+	c.postRoll -= 1.0 / clipFPS
+	if c.postRoll > 0 {
+		return
+	}
+
+	// Hand the frames, oldest first, to a background save
+	frames := make([]*image.RGBA, 0, c.count)
+	for i := 0; i < c.count; i++ {
+		frames = append(frames, c.frames[(c.next-c.count+i+len(c.frames))%len(c.frames)])
+	}
+	reason, keep := c.reason, c.keep
+	c.reason = ""
+	go func() {
+		path, err := saveClip(frames, reason, keep)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save clip: %v\n", err)
+			return
+		}
+		fmt.Println("Saved clip:", path)
+	}()
+}
+
+// captureFrame reads a raylib render texture back as an upscaled image
+func captureFrame(target rl.RenderTexture2D, scale int) *image.RGBA {
+	img := rl.LoadImageFromTexture(target.Texture)
+	defer rl.UnloadImage(img)
+	// Render textures come back upside down
+	rl.ImageFlipVertical(img)
+	colors := rl.LoadImageColors(img)
+	defer rl.UnloadImageColors(colors)
+
+	src := image.NewRGBA(image.Rect(0, 0, screenWidth, screenHeight))
+	for i, c := range colors[:screenWidth*screenHeight] {
+		src.Pix[i*4], src.Pix[i*4+1], src.Pix[i*4+2], src.Pix[i*4+3] = c.R, c.G, c.B, c.A
+	}
+	return scaleFrame(src, scale)
+}
+
+// saveClip writes frames as a GIF named after the time and highlight, then
+// deletes the oldest clips beyond the retention limit
+func saveClip(frames []*image.RGBA, reason string, keep int) (string, error) {
+	dir := getClipsDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("clip-%s-%s.gif", time.Now().Format("20060102-150405"), reason))
+
+	out, err := newFrameWriter(RenderOptions{Output: path, FPS: clipFPS})
+	if err != nil {
+		return "", err
+	}
+	for _, frame := range frames {
+		if err := out.Add(frame); err != nil {
+			return "", err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+
+	pruneClips(dir, keep)
+	return path, nil
+}
+
+// pruneClips deletes all but the newest keep clips
+func pruneClips(dir string, keep int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var clips []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "clip-") && strings.HasSuffix(entry.Name(), ".gif") {
+			clips = append(clips, entry.Name())
+		}
+	}
+	// Names start with the timestamp, so they sort oldest first
+	sort.Strings(clips)
+	for len(clips) > keep {
+		os.Remove(filepath.Join(dir, clips[0]))
+		clips = clips[1:]
+	}
+}
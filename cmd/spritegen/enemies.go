@@ -8,12 +8,12 @@ import (
 
 // Enemy sprite sheet generator
 // Each enemy is 32x16 pixels (wider for text)
-// Enemies: Bug, ERROR, LOW CONTEXT
+// Enemies: Bug, ERROR, LOW CONTEXT, $$$ (over budget)
 
 const (
 	enemyFrameWidth  = 32
 	enemyFrameHeight = 16
-	enemyNumTypes    = 3
+	enemyNumTypes    = 4
 	enemyMaxFrames   = 4 // Simple animation frames
 )
 
@@ -22,6 +22,7 @@ const (
 	EnemyBug = iota
 	EnemyError
 	EnemyLowContext
+	EnemyOverBudget
 )
 
 func generateEnemies() {
@@ -61,6 +62,8 @@ func drawEnemyFrame(img *image.RGBA, enemyType, frame int) {
 		drawErrorText(img, offsetX, offsetY, frame)
 	case EnemyLowContext:
 		drawLowContextText(img, offsetX, offsetY, frame)
+	case EnemyOverBudget:
+		drawBudgetText(img, offsetX, offsetY, frame)
 	}
 }
 
@@ -219,6 +222,31 @@ func drawLowContextText(img *image.RGBA, ox, oy, frame int) {
 	}
 }
 
+var (
+	budgetGold  = C{0xFF, 0xD7, 0x00, 0xFF} // Gold
+	budgetShine = C{0xFF, 0xF5, 0xB0, 0xFF} // Pale gold glint
+	budgetDark  = C{0x99, 0x66, 0x00, 0xFF} // Dark shadow
+)
+
+func drawBudgetText(img *image.RGBA, ox, oy, frame int) {
+	// Bounce animation, one dollar sign at a time
+	cx := ox + 3
+	cy := oy + 4
+
+	for i := 0; i < 3; i++ {
+		bounce := 0
+		if frame == i {
+			bounce = -1
+		}
+		drawPixelLetter(img, cx+i*9, cy+bounce, '$', budgetGold, budgetDark)
+	}
+
+	// Glint travels across the signs
+	if frame < 3 {
+		img.Set(cx+frame*9+1, cy+1, budgetShine)
+	}
+}
+
 // Simple 5x7 pixel font for main letters
 func drawPixelLetter(img *image.RGBA, ox, oy int, letter rune, main, shadow C) {
 	// Draw shadow first (offset by 1,1)
@@ -302,6 +330,15 @@ func drawLetterPixels(img *image.RGBA, ox, oy int, letter rune, c C) {
 			"#   #",
 			"#   #",
 		},
+		'$': {
+			"  #  ",
+			" ####",
+			"# #  ",
+			" ### ",
+			"  # #",
+			"#### ",
+			"  #  ",
+		},
 	}
 
 	pattern, ok := patterns[letter]
@@ -21,8 +21,28 @@ type Config struct {
 	SoundEnabled bool    `json:"sound_enabled"`
 	Volume       float32 `json:"volume"`
 
-	// Theme/background
-	Background string `json:"background"`
+	// Biome selection
+	Background string      `json:"background"`  // Biome to stay in ("auto" follows the rules)
+	BiomeDwell float64     `json:"biome_dwell"` // Seconds of walking before the biome can change (default 20)
+	BiomeFade  float64     `json:"biome_fade"`  // Crossfade between biomes in seconds (default 1.5)
+	BiomeRules []BiomeRule `json:"biome_rules"`
+
+	// Cost estimation (USD per million tokens, matched against model names)
+	Prices        map[string]ModelPrice `json:"prices"`
+	SessionBudget float64               `json:"session_budget"` // Warn when a session costs more (0 = off)
+	DailyBudget   float64               `json:"daily_budget"`   // Warn when a day costs more (0 = off)
+
+	// Rolling usage window shared by all sessions (stamina bar)
+	UsageWindowHours float64 `json:"usage_window_hours"`
+	UsageWindowLimit int64   `json:"usage_window_limit"` // Tokens per window (0 = busiest past window)
+
+	// Highlight clips saved to ~/.claude-quest/clips (off unless enabled)
+	Clips       bool    `json:"clips"`
+	ClipSeconds float64 `json:"clip_seconds"` // Clip length (default 8)
+	ClipsKeep   int     `json:"clips_keep"`   // Clips kept on disk (default 20)
+
+	// Streamer privacy: "off", "redact" or "categories" (cq serve defaults to "redact")
+	Privacy string `json:"privacy"`
 }
 
 // DefaultConfig returns a config with sensible defaults
@@ -33,7 +53,10 @@ func DefaultConfig() *Config {
 		Debug:        false,
 		SoundEnabled: true,
 		Volume:       0.7,
-		Background:   "study",
+		Background:   "auto",
+		Prices:       DefaultPrices(),
+
+		UsageWindowHours: 5,
 	}
 }
 
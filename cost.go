@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ModelPrice is the list price of a model in USD per million tokens
+type ModelPrice struct {
+	Input      float64 `json:"input"`
+	CacheWrite float64 `json:"cache_write"`
+	CacheRead  float64 `json:"cache_read"`
+	Output     float64 `json:"output"`
+}
+
+// DefaultPrices returns the built-in price table. Keys are matched against
+// the model name; the longest matching key wins, so "claude-opus-4-1" beats
+// "opus". The generic keys carry the current price of each family, and older
+// models that cost more have their own keys.
+func DefaultPrices() map[string]ModelPrice {
+	return map[string]ModelPrice{
+		"opus":               {Input: 5, CacheWrite: 6.25, CacheRead: 0.50, Output: 25},
+		"claude-opus-4-1":    {Input: 15, CacheWrite: 18.75, CacheRead: 1.50, Output: 75},
+		"claude-opus-4-2025": {Input: 15, CacheWrite: 18.75, CacheRead: 1.50, Output: 75}, // Opus 4, by its dated ID
+		"claude-3-opus":      {Input: 15, CacheWrite: 18.75, CacheRead: 1.50, Output: 75},
+		"sonnet":             {Input: 3, CacheWrite: 3.75, CacheRead: 0.30, Output: 15},
+		"haiku":              {Input: 1, CacheWrite: 1.25, CacheRead: 0.10, Output: 5},
+		"claude-3-5-haiku":   {Input: 0.80, CacheWrite: 1, CacheRead: 0.08, Output: 4},
+		"claude-3-haiku":     {Input: 0.25, CacheWrite: 0.30, CacheRead: 0.03, Output: 1.25},
+	}
+}
+
+// CostBreakdown is an estimated cost in USD, split by token kind
+type CostBreakdown struct {
+	Input      float64
+	CacheWrite float64
+	CacheRead  float64
+	Output     float64
+}
+
+// Add adds another breakdown to this one
+func (c *CostBreakdown) Add(o CostBreakdown) {
+	c.Input += o.Input
+	c.CacheWrite += o.CacheWrite
+	c.CacheRead += o.CacheRead
+	c.Output += o.Output
+}
+
+// Total returns the whole estimated cost
+func (c CostBreakdown) Total() float64 {
+	return c.Input + c.CacheWrite + c.CacheRead + c.Output
+}
+
+// PriceFor looks up the price of a model (false if no key matches)
+func (c *Config) PriceFor(model string) (ModelPrice, bool) {
+	model = strings.ToLower(model)
+	best := ""
+	for key := range c.Prices {
+		if strings.Contains(model, strings.ToLower(key)) && len(key) > len(best) {
+			best = key
+		}
+	}
+	if best == "" {
+		return ModelPrice{}, false
+	}
+	return c.Prices[best], true
+}
+
+// Cost estimates what a model's token totals cost
+func (c *Config) Cost(model string, t TokenTotals) CostBreakdown {
+	price, ok := c.PriceFor(model)
+	if !ok {
+		return CostBreakdown{}
+	}
+	const perToken = 1.0 / 1_000_000
+	return CostBreakdown{
+		Input:      float64(t.Input) * price.Input * perToken,
+		CacheWrite: float64(t.CacheCreation) * price.CacheWrite * perToken,
+		CacheRead:  float64(t.CacheRead) * price.CacheRead * perToken,
+		Output:     float64(t.Output) * price.Output * perToken,
+	}
+}
+
+// CostOf estimates the cost of token totals keyed by model
+func (c *Config) CostOf(byModel map[string]TokenTotals) CostBreakdown {
+	var total CostBreakdown
+	for model, t := range byModel {
+		total.Add(c.Cost(model, t))
+	}
+	return total
+}
+
+// formatCost formats a dollar amount for display
+func formatCost(usd float64) string {
+	if usd >= 100 {
+		return fmt.Sprintf("$%.0f", usd)
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// BudgetWatch remembers which budgets have already raised a warning
+type BudgetWatch struct {
+	SessionWarned bool
+	DailyWarnedOn string // Local date the daily warning fired on
+}
+
+// Check returns warnings for budgets crossed since the last check
+func (b *BudgetWatch) Check(config *Config, sessionCost, dailyCost float64) []string {
+	var warnings []string
+	if config.SessionBudget > 0 && sessionCost >= config.SessionBudget && !b.SessionWarned {
+		b.SessionWarned = true
+		warnings = append(warnings, fmt.Sprintf("Session budget of %s spent!", formatCost(config.SessionBudget)))
+	}
+	today := time.Now().Format("2006-01-02")
+	if config.DailyBudget > 0 && dailyCost >= config.DailyBudget && b.DailyWarnedOn != today {
+		b.DailyWarnedOn = today
+		warnings = append(warnings, fmt.Sprintf("Daily budget of %s spent!", formatCost(config.DailyBudget)))
+	}
+	return warnings
+}
+
+// OverBudget reports whether the session budget or today's budget is spent
+func (b *BudgetWatch) OverBudget() bool {
+	return b.SessionWarned || b.DailyWarnedOn == time.Now().Format("2006-01-02")
+}
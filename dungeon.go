@@ -0,0 +1,351 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// DUNGEON MAP
+// ============================================================================
+//
+// Each project is a dungeon and each directory a room. Reads and searches
+// light rooms up, edits leave loot in them and errors leave scorch marks. The
+// counts are kept per project in ~/.claude-quest/dungeons, so the map shows
+// which parts of the codebase Claude has been through over every session.
+
+const (
+	dungeonMaxRooms     = 400   // Directories scanned before giving up on the rest
+	dungeonMaxFiles     = 20000 // Files counted before giving up on the rest
+	dungeonMaxDepth     = 6
+	dungeonSaveInterval = 10 // Seconds between saves while exploring
+)
+
+// dungeonSkipDirs are never scanned: generated, vendored or too big to be rooms
+var dungeonSkipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true,
+	"__pycache__": true, "venv": true, "coverage": true,
+}
+
+// DungeonRoom is what Claude did in one directory
+type DungeonRoom struct {
+	Reads     int       `json:"reads"`
+	Edits     int       `json:"edits"`
+	Errors    int       `json:"errors"`
+	LastVisit time.Time `json:"last_visit"`
+}
+
+// Dungeon is the map of one project
+type Dungeon struct {
+	Project string                  `json:"project"`
+	Rooms   map[string]*DungeonRoom `json:"rooms"` // By slash-separated path from the project root ("." for the root)
+
+	files     map[string]int         // Files directly in each scanned directory
+	scanned   chan map[string]int    // Delivers the file counts when the scan is done
+	unsaved   map[string]DungeonRoom // Counts added since the last save
+	toolRooms map[string]string      // Tool use ID -> room, so errors land where the call went
+	current   string                 // Room Claude was in last
+	saveTimer float32
+	scratch   bool // Never saved (cq render)
+}
+
+// getDungeonPath returns the file a project's dungeon is kept in
+func getDungeonPath(project string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	h := fnv.New32a()
+	h.Write([]byte(project))
+	name := fmt.Sprintf("%s-%08x.json", filepath.Base(project), h.Sum32())
+	return filepath.Join(home, ".claude-quest", "dungeons", name)
+}
+
+// LoadDungeon loads a project's dungeon and starts scanning its directories
+// for the rooms not visited yet. The scan runs in the background, since a big
+// project takes a while to walk; Update picks up its result.
+func LoadDungeon(project string, scratch bool) *Dungeon {
+	d := &Dungeon{
+		Project:   project,
+		Rooms:     make(map[string]*DungeonRoom),
+		files:     make(map[string]int),
+		unsaved:   make(map[string]DungeonRoom),
+		toolRooms: make(map[string]string),
+		scanned:   make(chan map[string]int, 1),
+		scratch:   scratch,
+	}
+	if !scratch {
+		if data, err := os.ReadFile(getDungeonPath(project)); err == nil {
+			var saved Dungeon
+			if err := json.Unmarshal(data, &saved); err == nil && saved.Rooms != nil {
+				d.Rooms = saved.Rooms
+			}
+		}
+	}
+	go func(project string, scanned chan<- map[string]int) {
+		scanned <- scanDungeon(project)
+	}(project, d.scanned)
+	return d
+}
+
+// scanDungeon counts the files in each directory of a project
+func scanDungeon(project string) map[string]int {
+	files := make(map[string]int)
+	count := 0
+	filepath.WalkDir(project, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, relErr := filepath.Rel(project, path)
+		if relErr != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if !entry.IsDir() {
+			files[dungeonRoomOf(rel)]++
+			if count++; count >= dungeonMaxFiles {
+				return filepath.SkipAll
+			}
+			return nil
+		}
+		if rel != "." {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || dungeonSkipDirs[name] || strings.Count(rel, "/") >= dungeonMaxDepth {
+				return filepath.SkipDir
+			}
+		}
+		if len(files) >= dungeonMaxRooms {
+			return filepath.SkipAll
+		}
+		files[rel] = 0
+		return nil
+	})
+	return files
+}
+
+// takeScan swaps in the file counts once the scan is done, waiting for it if
+// asked to
+func (d *Dungeon) takeScan(wait bool) {
+	if d.scanned == nil {
+		return
+	}
+	if wait {
+		d.files = <-d.scanned
+	} else {
+		select {
+		case files := <-d.scanned:
+			d.files = files
+		default:
+			return
+		}
+	}
+	d.scanned = nil
+}
+
+// dungeonRoomOf returns the room a slash-separated file path is in
+func dungeonRoomOf(file string) string {
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		return file[:i]
+	}
+	return "."
+}
+
+// HandleEvent records a read, an edit or an error in the room it happened in
+func (d *Dungeon) HandleEvent(event Event) {
+	switch event.Type {
+	case EventReading, EventWriting:
+		room, ok := d.roomFor(event)
+		if !ok {
+			return
+		}
+		visit := DungeonRoom{Reads: 1}
+		if event.Type == EventWriting {
+			visit = DungeonRoom{Edits: 1}
+		}
+		d.add(room, visit, event.Timestamp)
+		if event.ToolUseID != "" {
+			d.toolRooms[event.ToolUseID] = room
+		}
+
+	case EventError:
+		room, ok := d.toolRooms[event.ToolUseID]
+		if !ok {
+			if d.current == "" {
+				return
+			}
+			room = d.current
+		}
+		d.add(room, DungeonRoom{Errors: 1}, event.Timestamp)
+	}
+}
+
+// roomFor works out which room a file tool went into
+func (d *Dungeon) roomFor(event Event) (string, bool) {
+	switch event.ToolName {
+	case "Read", "Edit", "MultiEdit", "Write", "NotebookEdit", "Glob", "Grep":
+	default:
+		return "", false
+	}
+
+	path := event.Path
+	if path == "" {
+		// Searches without a path run from the project root
+		return ".", event.ToolName == "Glob" || event.ToolName == "Grep"
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(d.Project, path)
+	}
+	rel, err := filepath.Rel(d.Project, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false // Outside the project
+	}
+	rel = filepath.ToSlash(rel)
+
+	// Searches name a directory, the rest a file
+	isDir := event.ToolName == "Glob" || event.ToolName == "Grep"
+	if _, scanned := d.files[rel]; scanned {
+		isDir = true
+	} else if filepath.Ext(rel) != "" {
+		isDir = false
+	}
+	if isDir {
+		return rel, true
+	}
+	return dungeonRoomOf(rel), true
+}
+
+// add counts a visit to a room
+func (d *Dungeon) add(room string, visit DungeonRoom, at time.Time) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	r := d.Rooms[room]
+	if r == nil {
+		r = &DungeonRoom{}
+		d.Rooms[room] = r
+	}
+	r.Reads += visit.Reads
+	r.Edits += visit.Edits
+	r.Errors += visit.Errors
+	if at.After(r.LastVisit) {
+		r.LastVisit = at
+	}
+
+	u := d.unsaved[room]
+	u.Reads += visit.Reads
+	u.Edits += visit.Edits
+	u.Errors += visit.Errors
+	u.LastVisit = r.LastVisit
+	d.unsaved[room] = u
+	d.current = room
+}
+
+// Update picks up the scan when it's done and saves the dungeon every few
+// seconds while Claude explores
+func (d *Dungeon) Update(dt float32) {
+	d.takeScan(false)
+	if len(d.unsaved) == 0 {
+		return
+	}
+	d.saveTimer += dt
+	if d.saveTimer >= dungeonSaveInterval {
+		d.Save()
+	}
+}
+
+// Save adds this session's visits to the dungeon on disk under a lock, so
+// other instances exploring the same project don't overwrite each other
+func (d *Dungeon) Save() error {
+	d.saveTimer = 0
+	if d.scratch || len(d.unsaved) == 0 {
+		return nil
+	}
+
+	path := getDungeonPath(d.Project)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return withFileLock(path+".lock", func() error {
+		return d.mergeInto(path)
+	})
+}
+
+// mergeInto re-reads the dungeon file, adds the unsaved visits and writes it
+// back. Must be called with the dungeon's lock held.
+func (d *Dungeon) mergeInto(path string) error {
+	rooms := make(map[string]*DungeonRoom)
+	if data, err := os.ReadFile(path); err == nil {
+		var saved Dungeon
+		if err := json.Unmarshal(data, &saved); err == nil && saved.Rooms != nil {
+			rooms = saved.Rooms
+		}
+	}
+	for name, u := range d.unsaved {
+		r := rooms[name]
+		if r == nil {
+			r = &DungeonRoom{}
+			rooms[name] = r
+		}
+		r.Reads += u.Reads
+		r.Edits += u.Edits
+		r.Errors += u.Errors
+		if u.LastVisit.After(r.LastVisit) {
+			r.LastVisit = u.LastVisit
+		}
+	}
+
+	data, err := json.MarshalIndent(&Dungeon{Project: d.Project, Rooms: rooms}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return err
+	}
+
+	// Pick up what other instances found
+	d.Rooms = rooms
+	d.unsaved = make(map[string]DungeonRoom)
+	return nil
+}
+
+// Explored returns how many of the project's rooms Claude has been in, and
+// how many there are
+func (d *Dungeon) Explored() (int, int) {
+	explored, total := 0, 0
+	for name := range d.allRooms() {
+		total++
+		if r := d.Rooms[name]; r != nil && r.Reads+r.Edits > 0 {
+			explored++
+		}
+	}
+	return explored, total
+}
+
+// allRooms returns every room: scanned, visited and the ones in between
+func (d *Dungeon) allRooms() map[string]bool {
+	rooms := map[string]bool{".": true}
+	addWithParents := func(name string) {
+		for name != "." && !rooms[name] {
+			rooms[name] = true
+			name = dungeonRoomOf(name)
+		}
+	}
+	for name := range d.files {
+		addWithParents(name)
+	}
+	for name := range d.Rooms {
+		addWithParents(name)
+	}
+	return rooms
+}
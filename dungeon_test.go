@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestDungeon(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+	project := t.TempDir()
+	for _, dir := range []string{"src/api", "docs", "node_modules/left-pad", ".git"} {
+		if err := os.MkdirAll(filepath.Join(project, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(project, "src", "api", "server.go"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := LoadDungeon(project, false)
+	a.takeScan(true)
+	if _, total := a.Explored(); total != 4 {
+		t.Errorf("project has %d rooms, want 4 (root, src, src/api, docs)", total)
+	}
+	a.HandleEvent(Event{Type: EventReading, ToolName: "Read", Path: filepath.Join(project, "src", "api", "server.go")})
+	a.HandleEvent(Event{Type: EventReading, ToolName: "Grep", Path: filepath.Join(project, "docs")})
+	a.HandleEvent(Event{Type: EventReading, ToolName: "Glob"})
+	a.HandleEvent(Event{Type: EventReading, ToolName: "Read", Path: "/etc/hosts"})
+	a.HandleEvent(Event{Type: EventReading, ToolName: "WebFetch"})
+	a.HandleEvent(Event{Type: EventWriting, ToolName: "Edit", Path: "src/api/server.go", ToolUseID: "e1"})
+	a.HandleEvent(Event{Type: EventError, ToolUseID: "e1"})
+
+	api := a.Rooms["src/api"]
+	if api == nil || api.Reads != 1 || api.Edits != 1 || api.Errors != 1 {
+		t.Fatalf("src/api is %+v, want 1 read, 1 edit and 1 error", api)
+	}
+	if a.Rooms["docs"] == nil || a.Rooms["."] == nil || len(a.Rooms) != 3 {
+		t.Errorf("visited rooms %v, want src/api, docs and the root", a.Rooms)
+	}
+
+	// Two instances exploring the same project both keep their visits
+	b := LoadDungeon(project, false)
+	b.HandleEvent(Event{Type: EventReading, ToolName: "Read", Path: filepath.Join(project, "src", "api", "server.go")})
+	if err := a.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadDungeon(project, false).Rooms["src/api"].Reads; got != 2 {
+		t.Errorf("src/api has %d reads on disk, want 2", got)
+	}
+
+	// ...and so do instances saving at the same time
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		c := LoadDungeon(project, false)
+		c.HandleEvent(Event{Type: EventReading, ToolName: "Grep", Path: filepath.Join(project, "docs")})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Save(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := LoadDungeon(project, false).Rooms["docs"].Reads; got != 9 {
+		t.Errorf("docs has %d reads on disk after concurrent saves, want 9", got)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import "math"
+
+// ============================================================================
+// EMOTIONS
+// ============================================================================
+//
+// Claude's face shows how the session feels, whatever the body is doing. Each
+// event stirs a feeling - a run of tool calls focuses Claude, errors frustrate
+// it, a push makes it proud - and every feeling fades with time. The strongest
+// one that is strong enough shows on the face; otherwise the sprite keeps its
+// own. A long quiet stretch makes Claude sleepy.
+
+// Emotion is a feeling that can show on Claude's face
+type Emotion int
+
+const (
+	EmotionNeutral Emotion = iota // The sprite's own face
+	EmotionFocused
+	EmotionHappy
+	EmotionFrustrated
+	EmotionSleepy
+	EmotionProud
+	EmotionAnxious
+	emotionCount
+)
+
+const (
+	emotionShow       = 0.5  // Strength a feeling needs to show
+	emotionRelief     = 5    // Clean tool calls after frustration that bring relief
+	emotionSleepAfter = 45   // Seconds without events before Claude gets drowsy
+	emotionSleepTime  = 60   // Seconds more until Claude is fully sleepy
+	emotionFullMana   = 0.85 // Share of the context window that makes Claude anxious
+)
+
+// emotionHalfLife is how many seconds each feeling takes to fade by half
+var emotionHalfLife = [emotionCount]float64{
+	EmotionFocused:    8,
+	EmotionHappy:      15,
+	EmotionFrustrated: 20,
+	EmotionProud:      30,
+	EmotionAnxious:    25,
+}
+
+// Mood tracks how strongly Claude feels each emotion
+type Mood struct {
+	levels [emotionCount]float32
+	idle   float32 // Seconds since the last event
+	clean  int     // Tool calls since the last error
+}
+
+// feel stirs an emotion, up to full strength
+func (m *Mood) feel(e Emotion, amount float32) {
+	m.levels[e] = float32(math.Min(float64(m.levels[e]+amount), 1))
+}
+
+// HandleEvent stirs the feelings an event brings
+func (m *Mood) HandleEvent(event Event) {
+	if event.Type == EventIdle {
+		return
+	}
+	m.idle = 0
+	m.levels[EmotionSleepy] = 0
+
+	switch event.Type {
+	case EventReading, EventWriting, EventBash, EventThinkHard:
+		m.feel(EmotionFocused, 0.12)
+		m.clean++
+		if m.clean == emotionRelief && m.levels[EmotionFrustrated] > 0.3 {
+			// Things work again
+			m.levels[EmotionFrustrated] /= 2
+			m.feel(EmotionHappy, 1)
+		}
+
+	case EventError:
+		m.clean = 0
+		m.feel(EmotionFrustrated, 0.3)
+		m.levels[EmotionHappy] /= 2
+
+	case EventTodoUpdate:
+		for _, todo := range event.TodoItems {
+			if todo.Status == "completed" {
+				m.feel(EmotionHappy, 0.2)
+				break
+			}
+		}
+
+	case EventSuccess, EventAgentComplete:
+		m.feel(EmotionHappy, 0.35)
+
+	case EventGitPush:
+		// Pride takes over from everything else
+		m.feel(EmotionProud, 1)
+		m.levels[EmotionFrustrated] = 0
+		m.levels[EmotionHappy] /= 2
+
+	case EventVictoryPose:
+		m.feel(EmotionProud, 0.6)
+
+	case EventCompact:
+		// Losing the conversation is unsettling
+		m.feel(EmotionAnxious, 0.6)
+
+	case EventAskUser:
+		m.feel(EmotionAnxious, 0.35)
+	}
+
+	if event.TokenUsage != nil && float32(event.TokenUsage.Total()) >= emotionFullMana*maxTokens {
+		m.feel(EmotionAnxious, 0.15)
+	}
+}
+
+// Update fades every feeling and lets Claude get sleepy when nothing happens
+func (m *Mood) Update(dt float32) {
+	for e, halfLife := range emotionHalfLife {
+		if halfLife > 0 {
+			m.levels[e] *= float32(math.Pow(0.5, float64(dt)/halfLife))
+		}
+	}
+	m.idle += dt
+	if m.idle > emotionSleepAfter {
+		m.levels[EmotionSleepy] = float32(math.Min(float64(m.idle-emotionSleepAfter)/emotionSleepTime, 1))
+	}
+}
+
+// Level returns how strongly Claude feels an emotion, from 0 to 1
+func (m *Mood) Level(e Emotion) float32 {
+	return m.levels[e]
+}
+
+// Dominant returns the strongest feeling if it's strong enough to show
+func (m *Mood) Dominant() Emotion {
+	best := EmotionNeutral
+	for e := EmotionFocused; e < emotionCount; e++ {
+		if m.levels[e] >= emotionShow && (best == EmotionNeutral || m.levels[e] > m.levels[best]) {
+			best = e
+		}
+	}
+	return best
+}
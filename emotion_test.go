@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestMood(t *testing.T) {
+	var m Mood
+	expect := func(want Emotion) {
+		t.Helper()
+		if got := m.Dominant(); got != want {
+			t.Fatalf("emotion is %d, want %d", got, want)
+		}
+	}
+	expect(EmotionNeutral)
+
+	// A run of tool calls focuses Claude, and it fades once they stop
+	for i := 0; i < 5; i++ {
+		m.HandleEvent(Event{Type: EventReading})
+	}
+	expect(EmotionFocused)
+	m.Update(10)
+	expect(EmotionNeutral)
+
+	// Errors frustrate, and a clean stretch afterwards is a relief
+	m.HandleEvent(Event{Type: EventError})
+	m.HandleEvent(Event{Type: EventError})
+	m.HandleEvent(Event{Type: EventError})
+	expect(EmotionFrustrated)
+	for i := 0; i < emotionRelief; i++ {
+		m.HandleEvent(Event{Type: EventBash})
+	}
+	expect(EmotionHappy)
+
+	// Shipping beats everything
+	m.HandleEvent(Event{Type: EventGitPush})
+	expect(EmotionProud)
+
+	// A long quiet stretch makes Claude sleepy, and any event wakes it
+	for i := 0; i < 120; i++ {
+		m.Update(1)
+	}
+	expect(EmotionSleepy)
+	m.HandleEvent(Event{Type: EventIdle})
+	expect(EmotionSleepy)
+	m.HandleEvent(Event{Type: EventCompact})
+	expect(EmotionAnxious)
+}
@@ -0,0 +1,63 @@
+package main
+
+// ============================================================================
+// COMPACTION FORECAST
+// ============================================================================
+//
+// Each assistant message reports the full context size, so the difference
+// between successive messages is how much one turn grows the context. A
+// smoothed growth rate turns the room left before auto-compact into a turn
+// count the mana bar can show.
+
+const (
+	autoCompactRatio  = 0.92 // Approximate context usage at which Claude Code auto-compacts
+	forecastWarnTurns = 5    // Warn when fewer turns than this are left
+	forecastSmoothing = 0.3  // Weight of the newest turn in the growth average
+)
+
+// ContextForecast estimates how many turns are left before auto-compact
+type ContextForecast struct {
+	Growth float32 // Smoothed context growth per turn, in tokens
+	Warned bool    // Low-turns warning already raised (cleared after compaction)
+
+	lastMessage string
+	lastContext int
+}
+
+// Observe records the context size reported by a message and returns true
+// if this is the first time the message was seen (a new turn)
+func (f *ContextForecast) Observe(messageID string, context int) bool {
+	if messageID == f.lastMessage {
+		return false
+	}
+	f.lastMessage = messageID
+
+	if f.lastContext > 0 && context > f.lastContext {
+		growth := float32(context - f.lastContext)
+		if f.Growth == 0 {
+			f.Growth = growth
+		} else {
+			f.Growth += (growth - f.Growth) * forecastSmoothing
+		}
+	}
+	f.lastContext = context
+	return true
+}
+
+// Compacted resets the baseline after the context was compacted
+func (f *ContextForecast) Compacted() {
+	f.lastContext = 0
+	f.Warned = false
+}
+
+// TurnsLeft returns the estimated turns until auto-compact (-1 if unknown)
+func (f *ContextForecast) TurnsLeft(context, max int) int {
+	if f.Growth <= 0 || context <= 0 {
+		return -1
+	}
+	room := float32(max)*autoCompactRatio - float32(context)
+	if room <= 0 {
+		return 0
+	}
+	return int(room / f.Growth)
+}
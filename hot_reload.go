@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
-	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 // HotReloader watches asset files and triggers reloads
@@ -163,36 +162,36 @@ func (hr *HotReloader) reloadTexture(path string) {
 	if strings.Contains(path, "spritesheet.png") && !strings.Contains(path, "mini") && !strings.Contains(path, "enemy") {
 		// Main sprite sheet
 		if hr.renderer.spriteSheet.ID != 0 {
-			rl.UnloadTexture(hr.renderer.spriteSheet)
+			hr.renderer.canvas.UnloadTexture(hr.renderer.spriteSheet)
 		}
-		hr.renderer.spriteSheet = rl.LoadTexture(path)
+		hr.renderer.spriteSheet = hr.renderer.canvas.LoadTexture(path)
 		hr.renderer.hasSprites = true
 		fmt.Printf("Reloaded: main spritesheet\n")
 
 	} else if strings.Contains(path, "mini_spritesheet.png") {
 		// Mini sprite sheet
 		if hr.renderer.miniSpriteSheet.ID != 0 {
-			rl.UnloadTexture(hr.renderer.miniSpriteSheet)
+			hr.renderer.canvas.UnloadTexture(hr.renderer.miniSpriteSheet)
 		}
-		hr.renderer.miniSpriteSheet = rl.LoadTexture(path)
+		hr.renderer.miniSpriteSheet = hr.renderer.canvas.LoadTexture(path)
 		hr.renderer.hasMiniSprites = true
 		fmt.Printf("Reloaded: mini spritesheet\n")
 
 	} else if strings.Contains(path, "enemy_spritesheet.png") {
 		// Enemy sprite sheet
 		if hr.renderer.enemySpriteSheet.ID != 0 {
-			rl.UnloadTexture(hr.renderer.enemySpriteSheet)
+			hr.renderer.canvas.UnloadTexture(hr.renderer.enemySpriteSheet)
 		}
-		hr.renderer.enemySpriteSheet = rl.LoadTexture(path)
+		hr.renderer.enemySpriteSheet = hr.renderer.canvas.LoadTexture(path)
 		hr.renderer.hasEnemySprites = true
 		fmt.Printf("Reloaded: enemy spritesheet\n")
 
 	} else if strings.Contains(path, "chest.png") {
 		// Chest texture
 		if hr.renderer.chestTexture.ID != 0 {
-			rl.UnloadTexture(hr.renderer.chestTexture)
+			hr.renderer.canvas.UnloadTexture(hr.renderer.chestTexture)
 		}
-		hr.renderer.chestTexture = rl.LoadTexture(path)
+		hr.renderer.chestTexture = hr.renderer.canvas.LoadTexture(path)
 		hr.renderer.hasChestTexture = true
 		fmt.Printf("Reloaded: chest texture\n")
 
@@ -215,16 +214,16 @@ func (hr *HotReloader) reloadHat(path string) {
 	for i, hatName := range hr.renderer.hatNames {
 		if hatName == name {
 			if hr.renderer.hats[i].ID != 0 {
-				rl.UnloadTexture(hr.renderer.hats[i])
+				hr.renderer.canvas.UnloadTexture(hr.renderer.hats[i])
 			}
-			hr.renderer.hats[i] = rl.LoadTexture(path)
+			hr.renderer.hats[i] = hr.renderer.canvas.LoadTexture(path)
 			fmt.Printf("Reloaded: hat '%s'\n", name)
 			return
 		}
 	}
 	// New hat - add it
 	hr.renderer.hatNames = append(hr.renderer.hatNames, name)
-	hr.renderer.hats = append(hr.renderer.hats, rl.LoadTexture(path))
+	hr.renderer.hats = append(hr.renderer.hats, hr.renderer.canvas.LoadTexture(path))
 	fmt.Printf("Added new hat: '%s'\n", name)
 }
 
@@ -234,16 +233,16 @@ func (hr *HotReloader) reloadFace(path string) {
 	for i, faceName := range hr.renderer.faceNames {
 		if faceName == name {
 			if hr.renderer.faces[i].ID != 0 {
-				rl.UnloadTexture(hr.renderer.faces[i])
+				hr.renderer.canvas.UnloadTexture(hr.renderer.faces[i])
 			}
-			hr.renderer.faces[i] = rl.LoadTexture(path)
+			hr.renderer.faces[i] = hr.renderer.canvas.LoadTexture(path)
 			fmt.Printf("Reloaded: face '%s'\n", name)
 			return
 		}
 	}
 	// New face - add it
 	hr.renderer.faceNames = append(hr.renderer.faceNames, name)
-	hr.renderer.faces = append(hr.renderer.faces, rl.LoadTexture(path))
+	hr.renderer.faces = append(hr.renderer.faces, hr.renderer.canvas.LoadTexture(path))
 	fmt.Printf("Added new face: '%s'\n", name)
 }
 
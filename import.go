@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ImportLedger remembers which transcripts have already been imported,
+// so running `cq import` twice never grants the same XP twice
+type ImportLedger struct {
+	// Cutoff is the moment live tracking began; only older events are imported.
+	// Fixed on the first import so later runs line up with the recorded counts.
+	Cutoff time.Time              `json:"cutoff"`
+	Files  map[string]LedgerEntry `json:"files"`
+}
+
+// LedgerEntry records how much of a transcript has been credited
+type LedgerEntry struct {
+	Events     int       `json:"events"` // Eligible events already credited
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// ImportSummary holds the stats computed from historical transcripts
+type ImportSummary struct {
+	FilesScanned  int
+	FilesImported int // Transcripts with new events to credit
+	Events        int
+	Earliest      time.Time
+	Stats         *CareerProfile // Scratch profile holding only the imported stats
+	ledgerUpdates map[string]int
+}
+
+// getImportLedgerPath returns the path to the import ledger JSON file
+func getImportLedgerPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".claude-quest-import.json"
+	}
+	return filepath.Join(home, ".claude-quest-import.json")
+}
+
+// LoadImportLedger loads the import ledger from disk, or creates an empty one
+func LoadImportLedger() *ImportLedger {
+	ledger := &ImportLedger{Files: make(map[string]LedgerEntry)}
+
+	data, err := os.ReadFile(getImportLedgerPath())
+	if err != nil {
+		return ledger
+	}
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return &ImportLedger{Files: make(map[string]LedgerEntry)}
+	}
+	if ledger.Files == nil {
+		ledger.Files = make(map[string]LedgerEntry)
+	}
+	return ledger
+}
+
+// Save writes the ledger to disk atomically (temp file + rename)
+func (l *ImportLedger) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	ledgerPath := getImportLedgerPath()
+	tempPath := ledgerPath + ".tmp"
+
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, ledgerPath)
+}
+
+// findAllTranscripts returns every conversation file under the config dir's projects folder
+func findAllTranscripts() ([]string, error) {
+	configDir, err := claudeConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Claude config directory: %w", err)
+	}
+	projectsDir := filepath.Join(configDir, "projects")
+
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", projectsDir, err)
+	}
+
+	var files []string
+	for _, project := range entries {
+		if !project.IsDir() {
+			continue
+		}
+		projectDir := filepath.Join(projectsDir, project.Name())
+		convs, err := os.ReadDir(projectDir)
+		if err != nil {
+			continue
+		}
+		for _, conv := range convs {
+			name := conv.Name()
+			// Skip agent files - subagent work shows up in the parent session
+			if conv.IsDir() || !strings.HasSuffix(name, ".jsonl") || strings.HasPrefix(name, "agent-") {
+				continue
+			}
+			files = append(files, filepath.Join(projectDir, name))
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// lineTimestamp extracts the timestamp of a transcript line (zero if missing)
+func lineTimestamp(line string) time.Time {
+	var meta struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if json.Unmarshal([]byte(line), &meta) != nil || meta.Timestamp == "" {
+		return time.Time{}
+	}
+	ts, err := time.Parse(time.RFC3339Nano, meta.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return ts
+}
+
+// scanTranscripts replays transcripts through the watcher's parser and the
+// same progression rules as live play. Only events from before cutoff are
+// counted (later ones were already tracked live), and events the ledger has
+// already credited are skipped.
+func scanTranscripts(files []string, ledger *ImportLedger, cutoff time.Time) *ImportSummary {
+	summary := &ImportSummary{
+		Stats:         newEmptyProfile(),
+		ledgerUpdates: make(map[string]int),
+	}
+
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		summary.FilesScanned++
+
+		w := NewWatcher()
+		var session SessionStats
+		var todos []TodoItem
+		discard := newEmptyProfile()
+		already := ledger.Files[path].Events
+		eligible := 0
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 1024*1024), 10*1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+			ts := lineTimestamp(line)
+			if ts.IsZero() || !ts.Before(cutoff) {
+				continue
+			}
+
+			for _, evt := range w.parseLine(line) {
+				eligible++
+				if eligible <= already {
+					// Keep session state in step, but don't credit again
+					applyProgression(discard, &session, todos, evt)
+				} else {
+					applyProgression(summary.Stats, &session, todos, evt)
+					summary.Events++
+					if summary.Earliest.IsZero() || ts.Before(summary.Earliest) {
+						summary.Earliest = ts
+					}
+				}
+				if evt.Type == EventTodoUpdate && evt.TodoItems != nil {
+					todos = evt.TodoItems
+				}
+			}
+		}
+		file.Close()
+
+		if eligible > already {
+			summary.FilesImported++
+			summary.ledgerUpdates[path] = eligible
+		}
+	}
+
+	return summary
+}
+
+// Apply folds the imported stats into a career profile and returns true on level-up
+func (s *ImportSummary) Apply(p *CareerProfile) bool {
+	st := s.Stats
+	p.TotalReads += st.TotalReads
+	p.TotalWrites += st.TotalWrites
+	p.TotalBash += st.TotalBash
+	p.BashSuccesses += st.BashSuccesses
+	for level, count := range st.TotalThinking {
+		p.TotalThinking[level] += count
+	}
+	p.TodosCompleted += st.TodosCompleted
+	p.AgentsCompleted += st.AgentsCompleted
+	p.Tokens.Add(st.Tokens)
+	for day, totals := range st.TokensByDay {
+		dayTotals := p.TokensByDay[day]
+		dayTotals.Add(totals)
+		p.TokensByDay[day] = dayTotals
+	}
+	for day, models := range st.ModelTokensByDay {
+		if p.ModelTokensByDay[day] == nil {
+			p.ModelTokensByDay[day] = make(map[string]TokenTotals)
+		}
+		for model, totals := range models {
+			modelTotals := p.ModelTokensByDay[day][model]
+			modelTotals.Add(totals)
+			p.ModelTokensByDay[day][model] = modelTotals
+		}
+	}
+	for lang, stats := range st.Languages {
+		total := p.Languages[lang]
+		total.Reads += stats.Reads
+		total.Edits += stats.Edits
+		total.Lines += stats.Lines
+		p.Languages[lang] = total
+	}
+	p.SessionsStarted += s.FilesImported
+	if st.BestBashStreak > p.BestBashStreak {
+		p.BestBashStreak = st.BestBashStreak
+	}
+	if !s.Earliest.IsZero() && s.Earliest.Before(p.FirstSeen) {
+		p.FirstSeen = s.Earliest
+	}
+	return p.AddXP(st.XP)
+}
+
+// Print writes a human-readable summary of what an import would grant
+func (s *ImportSummary) Print(p *CareerProfile) {
+	st := s.Stats
+	thinking := 0
+	for _, count := range st.TotalThinking {
+		thinking += count
+	}
+	newLevel := LevelFromXP(p.XP + st.XP)
+
+	fmt.Printf("  Transcripts scanned: %d\n", s.FilesScanned)
+	fmt.Printf("  To import:           %d transcript(s), %d events\n", s.FilesImported, s.Events)
+	if !s.Earliest.IsZero() {
+		fmt.Printf("  Earliest activity:   %s\n", s.Earliest.Local().Format("2006-01-02"))
+	}
+	fmt.Println()
+	fmt.Printf("  Reads:               %d\n", st.TotalReads)
+	fmt.Printf("  Writes:              %d\n", st.TotalWrites)
+	fmt.Printf("  Bash:                %d (%d succeeded)\n", st.TotalBash, st.BashSuccesses)
+	fmt.Printf("  Best bash streak:    %d\n", st.BestBashStreak)
+	fmt.Printf("  Thinking:            %d\n", thinking)
+	fmt.Printf("  Todos completed:     %d\n", st.TodosCompleted)
+	fmt.Printf("  Agents completed:    %d\n", st.AgentsCompleted)
+	fmt.Printf("  Tokens:              %s\n", formatTokenTotals(st.Tokens))
+	fmt.Println()
+	fmt.Printf("  XP:                  +%d (%d -> %d)\n", st.XP, p.XP, p.XP+st.XP)
+	fmt.Printf("  Level:               %d -> %d\n", p.Level, newLevel)
+}
+
+// runImport scans historical transcripts and credits them to the career profile
+func runImport(args []string) {
+	dryRun := false
+	assumeYes := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		case "--yes", "-y":
+			assumeYes = true
+		}
+	}
+
+	fmt.Println("Claude Quest Import")
+	fmt.Println("===================")
+	fmt.Println()
+
+	files, err := findAllTranscripts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	profile := LoadProfile()
+	ledger := LoadImportLedger()
+
+	// Anything after the profile was created has already been counted live
+	if ledger.Cutoff.IsZero() {
+		ledger.Cutoff = profile.FirstSeen
+	}
+	summary := scanTranscripts(files, ledger, ledger.Cutoff)
+	summary.Print(profile)
+	fmt.Println()
+
+	if summary.Events == 0 {
+		fmt.Println("Nothing new to import.")
+		return
+	}
+	if dryRun {
+		fmt.Println("Dry run - nothing was changed.")
+		return
+	}
+
+	if !assumeYes {
+		fmt.Print("Apply these stats to your profile? [y/N] ")
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Import cancelled.")
+			return
+		}
+	}
+
+	leveledUp := summary.Apply(profile)
+	if err := profile.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to save profile: %v\n", err)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	for path, events := range summary.ledgerUpdates {
+		ledger.Files[path] = LedgerEntry{Events: events, ImportedAt: now}
+	}
+	if err := ledger.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save import ledger: %v\n", err)
+	}
+
+	fmt.Printf("Imported %d events from %d transcript(s).\n", summary.Events, summary.FilesImported)
+	if leveledUp {
+		fmt.Printf("Reached level %d!\n", profile.Level)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ============================================================================
+// JOURNEY
+// ============================================================================
+//
+// Claude's walk doesn't end when the window closes. Every second of activity
+// adds to the distance in the career profile, and the moments worth keeping -
+// the first push of the day, level-ups, bonus chests - are left along the road
+// as landmarks. The world map lays the biomes out as regions on that road; each
+// opens up once Claude has walked far enough and reached a high enough level.
+
+const (
+	journeyWalkSpeed    = 1.4   // Metres per second of activity, a steady stroll
+	journeyRegionLength = 10000 // Metres of road through each region
+	journeyRegionLevels = 3     // Levels between one region and the next
+	journeyMaxLandmarks = 500   // Older landmarks are dropped past this many
+)
+
+// Landmark kinds
+const (
+	LandmarkPush  = "push"
+	LandmarkLevel = "level"
+	LandmarkChest = "chest"
+)
+
+// Landmark is a milestone left on the road
+type Landmark struct {
+	Kind     string    `json:"kind"`
+	Label    string    `json:"label"`
+	Distance float64   `json:"distance"` // Metres into the journey
+	Time     time.Time `json:"time"`
+}
+
+// Journey is the road Claude has walked
+type Journey struct {
+	Distance  float64    `json:"distance"`  // Metres walked
+	Landmarks []Landmark `json:"landmarks"` // Oldest first
+}
+
+// Walk adds dt seconds of activity to the distance
+func (p *CareerProfile) Walk(dt float32) {
+	p.Journey.Distance += float64(dt) * journeyWalkSpeed
+}
+
+// AddLandmark leaves a landmark where Claude is now
+func (p *CareerProfile) AddLandmark(kind, label string, at time.Time) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	p.Journey.Landmarks = append(p.Journey.Landmarks, Landmark{
+		Kind:     kind,
+		Label:    label,
+		Distance: p.Journey.Distance,
+		Time:     at,
+	})
+}
+
+// RecordPush leaves a landmark for the first push of the day.
+// Returns true if this was it.
+func (p *CareerProfile) RecordPush(at time.Time) bool {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	day := at.Local().Format("2006-01-02")
+	landmarks := p.Journey.Landmarks
+	for i := len(landmarks) - 1; i >= 0; i-- {
+		if landmarks[i].Kind == LandmarkPush && landmarks[i].Time.Local().Format("2006-01-02") == day {
+			return false
+		}
+	}
+	p.AddLandmark(LandmarkPush, "First push, "+at.Local().Format("Jan 2"), at)
+	return true
+}
+
+// mergeLandmarks adds the landmarks mem left since base to disk's, oldest
+// first, keeping the newest journeyMaxLandmarks
+func mergeLandmarks(disk, base, mem []Landmark) []Landmark {
+	merged := append([]Landmark(nil), disk...)
+	if len(mem) > len(base) {
+		merged = append(merged, mem[len(base):]...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	if len(merged) > journeyMaxLandmarks {
+		merged = merged[len(merged)-journeyMaxLandmarks:]
+	}
+	return merged
+}
+
+// regionOpen reports whether region i of the map is open at a distance and level
+func regionOpen(i int, distance float64, level int) bool {
+	return distance >= float64(i)*journeyRegionLength && level >= i*journeyRegionLevels
+}
+
+// journeyPosition returns how far along the road Claude stands: walking
+// carries on, but Claude waits at the edge of a region that isn't open yet
+func journeyPosition(regions int, distance float64, level int) float64 {
+	for i := 1; i < regions; i++ {
+		if !regionOpen(i, distance, level) {
+			return math.Min(distance, float64(i)*journeyRegionLength)
+		}
+	}
+	return math.Min(distance, float64(regions)*journeyRegionLength)
+}
+
+// regionRequirement describes what opens region i
+func regionRequirement(i int) string {
+	return fmt.Sprintf("%d km, Lv%d", i*journeyRegionLength/1000, i*journeyRegionLevels)
+}
+
+// formatDistance writes a distance in metres for the map
+func formatDistance(metres float64) string {
+	if metres < 1000 {
+		return fmt.Sprintf("%d m", int(metres))
+	}
+	return fmt.Sprintf("%.1f km", metres/1000)
+}
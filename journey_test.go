@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJourney(t *testing.T) {
+	day := time.Date(2025, time.June, 15, 9, 0, 0, 0, time.Local)
+	p := newEmptyProfile()
+	p.Walk(100)
+	if !p.RecordPush(day) {
+		t.Fatal("first push of the day should leave a landmark")
+	}
+	if p.RecordPush(day.Add(3 * time.Hour)) {
+		t.Fatal("second push of the day left a landmark")
+	}
+	if !p.RecordPush(day.Add(24 * time.Hour)) {
+		t.Fatal("first push of the next day should leave a landmark")
+	}
+	if got := p.Journey.Landmarks[0].Distance; got != 100*journeyWalkSpeed {
+		t.Errorf("landmark left at %v m, want %v", got, 100*journeyWalkSpeed)
+	}
+
+	// Claude waits at the edge of a region that isn't open yet
+	if got := journeyPosition(5, 2.5*journeyRegionLength, 0); got != journeyRegionLength {
+		t.Errorf("at level 0 position is %v, want %v", got, float64(journeyRegionLength))
+	}
+	if got := journeyPosition(5, 2.5*journeyRegionLength, 2*journeyRegionLevels); got != 2.5*journeyRegionLength {
+		t.Errorf("with both regions open position is %v", got)
+	}
+}
+
+func TestMergeJourney(t *testing.T) {
+	base := newEmptyProfile()
+	base.Journey.Distance = 1000
+	base.AddLandmark(LandmarkLevel, "Level 2", time.Unix(100, 0))
+
+	// Another instance walked on and levelled up while we pushed
+	disk := base.clone()
+	disk.Journey.Distance = 1500
+	disk.AddLandmark(LandmarkLevel, "Level 3", time.Unix(300, 0))
+	mem := base.clone()
+	mem.Journey.Distance = 1200
+	mem.AddLandmark(LandmarkPush, "First push", time.Unix(200, 0))
+
+	m := mergeProfile(disk, base, mem)
+	if m.Journey.Distance != 1700 {
+		t.Errorf("merged distance is %v, want 1700", m.Journey.Distance)
+	}
+	var labels []string
+	for _, landmark := range m.Journey.Landmarks {
+		labels = append(labels, landmark.Label)
+	}
+	want := []string{"Level 2", "First push", "Level 3"}
+	if len(labels) != len(want) || labels[0] != want[0] || labels[1] != want[1] || labels[2] != want[2] {
+		t.Errorf("merged landmarks %v, want %v", labels, want)
+	}
+}
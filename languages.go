@@ -0,0 +1,97 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ============================================================================
+// LANGUAGES
+// ============================================================================
+//
+// The extensions of the files Claude reads and edits say what stack it works
+// in. Thrown tool names take the language's color, errors send its nemesis
+// after Claude, and the career profile keeps lifetime stats per language.
+// Enough edits in some languages turn up a rare drop that no level-up offers.
+
+// languageDropEdits is how many edits in a language turn up its rare drop
+const languageDropEdits = 250
+
+// Language is a programming language, known by its file extensions
+type Language struct {
+	Name    string
+	Exts    []string
+	Color   uint32 // Packed RGBA, like the tool colors
+	Nemesis string // What the enemies its errors spawn are called
+	Drop    string // Item ID found after languageDropEdits edits ("" = none)
+}
+
+// Languages Claude can recognize, colored after their logos
+var Languages = []Language{
+	{Name: "Go", Exts: []string{".go"}, Color: 0x00ADD8FF, Nemesis: "nil", Drop: "trail_gopher"},
+	{Name: "Python", Exts: []string{".py", ".pyi", ".ipynb"}, Color: 0xFFD43BFF, Nemesis: "None", Drop: "aura_snake"},
+	{Name: "JavaScript", Exts: []string{".js", ".jsx", ".mjs", ".cjs"}, Color: 0xF7DF1EFF, Nemesis: "undefined"},
+	{Name: "TypeScript", Exts: []string{".ts", ".tsx", ".mts", ".cts"}, Color: 0x5A9BE6FF, Nemesis: "any"},
+	{Name: "Rust", Exts: []string{".rs"}, Color: 0xDEA584FF, Nemesis: "panic!"},
+	{Name: "Java", Exts: []string{".java"}, Color: 0xF89820FF, Nemesis: "NPE"},
+	{Name: "Kotlin", Exts: []string{".kt", ".kts"}, Color: 0xA97BFFFF, Nemesis: "!!"},
+	{Name: "C", Exts: []string{".c", ".h"}, Color: 0xA8B9CCFF, Nemesis: "segfault"},
+	{Name: "C++", Exts: []string{".cpp", ".cc", ".cxx", ".hpp", ".hh"}, Color: 0xF34B7DFF, Nemesis: "UB"},
+	{Name: "C#", Exts: []string{".cs"}, Color: 0xB57EDCFF, Nemesis: "null"},
+	{Name: "Ruby", Exts: []string{".rb"}, Color: 0xFF4F6FFF, Nemesis: "nil"},
+	{Name: "PHP", Exts: []string{".php"}, Color: 0x8892BFFF, Nemesis: "T_PAAMAYIM"},
+	{Name: "Swift", Exts: []string{".swift"}, Color: 0xF05138FF, Nemesis: "nil!"},
+	{Name: "Zig", Exts: []string{".zig"}, Color: 0xF7A41DFF, Nemesis: "unreachable"},
+	{Name: "Shell", Exts: []string{".sh", ".bash", ".zsh"}, Color: 0x89E051FF, Nemesis: "$?"},
+	{Name: "SQL", Exts: []string{".sql"}, Color: 0xE38C00FF, Nemesis: "NULL"},
+	{Name: "HTML", Exts: []string{".html", ".htm"}, Color: 0xE34C26FF, Nemesis: "</div>"},
+	{Name: "CSS", Exts: []string{".css", ".scss", ".sass", ".less"}, Color: 0x9F7BE1FF, Nemesis: "!important"},
+}
+
+// languageOf returns the language of a file by its extension
+func languageOf(path string) (*Language, bool) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" {
+		return nil, false
+	}
+	for i := range Languages {
+		for _, e := range Languages[i].Exts {
+			if e == ext {
+				return &Languages[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// LanguageStats is what Claude has done in one language
+type LanguageStats struct {
+	Reads int `json:"reads"`
+	Edits int `json:"edits"`
+	Lines int `json:"lines"` // Lines added and removed
+}
+
+// RecordLanguage counts a read or an edit of a file in its language.
+// Returns the ID of a rare drop Claude has earned but doesn't own yet, or "".
+func (p *CareerProfile) RecordLanguage(event Event) string {
+	lang, ok := languageOf(event.Path)
+	if !ok {
+		return ""
+	}
+	stats := p.Languages[lang.Name]
+	switch event.Type {
+	case EventReading:
+		stats.Reads++
+	case EventWriting:
+		stats.Edits++
+		stats.Lines += event.LinesAdded + event.LinesRemoved
+	default:
+		return ""
+	}
+	p.Languages[lang.Name] = stats
+
+	if lang.Drop != "" && stats.Edits >= languageDropEdits && !p.IsOwned(lang.Drop) {
+		return lang.Drop
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+func TestLanguageOf(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/src/main.go", "Go"},
+		{"app/Model.PY", "Python"},
+		{"web/App.tsx", "TypeScript"},
+		{"Makefile", ""},
+		{"README.md", ""},
+	}
+	for _, tt := range tests {
+		lang, ok := languageOf(tt.path)
+		got := ""
+		if ok {
+			got = lang.Name
+		}
+		if got != tt.want {
+			t.Errorf("languageOf(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageDrop(t *testing.T) {
+	p := newEmptyProfile()
+	edit := Event{Type: EventWriting, Path: "main.go", LinesAdded: 3}
+	for i := 1; i < languageDropEdits; i++ {
+		if drop := p.RecordLanguage(edit); drop != "" {
+			t.Fatalf("dropped %s after %d edits", drop, i)
+		}
+	}
+	p.RecordLanguage(Event{Type: EventReading, Path: "main.go"})
+	if drop := p.RecordLanguage(edit); drop != "trail_gopher" {
+		t.Fatalf("drop after %d Go edits is %q, want trail_gopher", languageDropEdits, drop)
+	}
+	want := LanguageStats{Reads: 1, Edits: languageDropEdits, Lines: 3 * languageDropEdits}
+	if got := p.Languages["Go"]; got != want {
+		t.Errorf("Go stats %+v, want %+v", got, want)
+	}
+
+	p.ClaimItem("trail_gopher")
+	if drop := p.RecordLanguage(edit); drop != "" {
+		t.Errorf("dropped %s again once owned", drop)
+	}
+
+	// Drops are never offered by chests
+	p.Level = 100
+	for _, item := range p.GetChoicePool() {
+		if item.Language != "" {
+			t.Errorf("%s is in the choice pool", item.ID)
+		}
+	}
+}
+
+func TestMergeLanguages(t *testing.T) {
+	base := newEmptyProfile()
+	base.Languages["Go"] = LanguageStats{Reads: 10, Edits: 5}
+
+	disk := base.clone()
+	disk.Languages["Go"] = LanguageStats{Reads: 12, Edits: 5}
+	mem := base.clone()
+	mem.Languages["Go"] = LanguageStats{Reads: 11, Edits: 7, Lines: 40}
+	mem.Languages["Python"] = LanguageStats{Reads: 1}
+
+	m := mergeProfile(disk, base, mem)
+	if got, want := m.Languages["Go"], (LanguageStats{Reads: 13, Edits: 7, Lines: 40}); got != want {
+		t.Errorf("merged Go stats %+v, want %+v", got, want)
+	}
+	if got := m.Languages["Python"].Reads; got != 1 {
+		t.Errorf("merged Python reads %d, want 1", got)
+	}
+}
@@ -59,17 +59,19 @@ const (
 	EnemyBug EnemyType = iota
 	EnemyError
 	EnemyLowContext
+	EnemyOverBudget
 )
 
 // FlyingEnemy represents an enemy flying toward Claude
 type FlyingEnemy struct {
-	Type    EnemyType
-	X, Y    float32 // Current position
-	VX, VY  float32 // Velocity (VX negative = moving left, VY affected by gravity)
-	Frame   int     // Animation frame
-	Timer   float32 // Animation timer
-	Hit     bool    // Has it hit Claude?
-	Impact  float32 // Impact effect timer (> 0 means showing impact)
+	Type     EnemyType
+	Language *Language // Language whose nemesis it is (nil = plain enemy)
+	X, Y     float32   // Current position
+	VX, VY   float32   // Velocity (VX negative = moving left, VY affected by gravity)
+	Frame    int       // Animation frame
+	Timer    float32   // Animation timer
+	Hit      bool      // Has it hit Claude?
+	Impact   float32   // Impact effect timer (> 0 means showing impact)
 }
 
 // FloatingXP represents a floating "+XP" indicator
@@ -80,6 +82,14 @@ type FloatingXP struct {
 	MaxLife float32
 }
 
+// FloatingDiff is a "+42 / -7" number that pops out of an edit
+type FloatingDiff struct {
+	Added, Removed int
+	X, Y, VY       float32
+	Timer          float32
+	MaxLife        float32
+}
+
 // GameState tracks UI state for quest text, mana bar, todos
 type GameState struct {
 	// Quest display
@@ -92,6 +102,13 @@ type GameState struct {
 	ManaMax     int
 	ManaDisplay float32 // Smoothly animated value
 
+	// Compaction forecast
+	Forecast         ContextForecast
+	CompactPreTokens int // Context size before the last compaction (until summarized)
+
+	// Prompt cache efficiency (cache rune)
+	Cache CacheMeter
+
 	// Todos
 	Todos []TodoItem
 
@@ -116,6 +133,9 @@ type GameState struct {
 	FlyingEnemies []FlyingEnemy
 	PendingHurt   bool // Set when enemy hits, triggers hurt animation
 
+	// Highlight worth a clip ("" = none), consumed by the clip recorder
+	PendingHighlight string
+
 	// Thought bubble display
 	ThoughtText  string  // Current thought text to display
 	ThoughtTimer float32 // Timer for thought display (6 seconds)
@@ -129,26 +149,63 @@ type GameState struct {
 	Profile *CareerProfile // Persistent career data
 	Session SessionStats   // Current session stats
 
+	// Cost estimation (gold counter)
+	Config      *Config     // Price table and budgets
+	SessionCost float64     // Estimated USD spent this session
+	Budgets     BudgetWatch // Budget warnings already raised
+
+	// Rolling usage window (stamina bar)
+	Usage   UsageWindow // Latest snapshot from the usage tracker
+	Resting bool        // Rate limited - resting at the inn until the window resets
+
+	// Streamer privacy - scrubs transcript text before it is shown
+	Privacy *PrivacyFilter
+
+	// Dungeon map of the project Claude is working in (nil until it's known)
+	Dungeon *Dungeon
+
+	// Language of the last file Claude read or edited (nil if none yet)
+	Language *Language
+
 	// Level up / chest state
 	PendingLevelUp    bool            // True when level up occurred, triggers chest
 	PendingBonusChest bool            // True when bonus chest triggered
 	BonusChestReason  string          // Why bonus chest was triggered
+	PendingDrop       string          // Item ID of a rare language drop awaiting its chest
 	ActiveChest       *TreasureChest  // Currently active treasure chest (nil if none)
 
 	// Floating XP indicators
 	FloatingXPs []FloatingXP
+
+	// Lines changed by recent edits
+	FloatingDiffs []FloatingDiff
 }
 
 // NewGameState creates a new game state
-func NewGameState() *GameState {
+func NewGameState(config *Config) *GameState {
 	profile := LoadProfile()
 	profile.SessionsStarted++
 	profile.Save()
 
+	return newGameState(config, profile)
+}
+
+// newGameState creates a game state playing with the given profile
+func newGameState(config *Config, profile *CareerProfile) *GameState {
 	return &GameState{
 		ManaMax:     maxTokens,
 		ManaDisplay: 0,
 		Profile:     profile,
+		Config:      config,
+		Privacy:     NewPrivacyFilter(config.Privacy),
+	}
+}
+
+// SaveDungeon writes the visits not saved yet to the dungeon map, for when
+// the game quits
+func (g *GameState) SaveDungeon() {
+	if g.Dungeon != nil {
+		g.Dungeon.Save()
 	}
 }
 
@@ -174,6 +231,9 @@ func (g *GameState) Update(dt float32) {
 		}
 	}
 
+	// Cache rune glow
+	g.Cache.Update(dt)
+
 	// Smooth mana animation
 	target := float32(g.ManaTotal)
 	if g.ManaDisplay < target {
@@ -230,6 +290,11 @@ func (g *GameState) Update(dt float32) {
 		}
 	}
 
+	// Rate limited - no walking until stamina is back
+	if g.Resting {
+		g.IsActive = false
+	}
+
 	// Activity timeout - go inactive after 60 seconds of no events
 	// (keeps walking during thinking pauses)
 	if g.IsActive {
@@ -239,6 +304,11 @@ func (g *GameState) Update(dt float32) {
 		}
 	}
 
+	// Every second of walking counts towards the journey
+	if g.IsActive && g.Profile != nil {
+		g.Profile.Walk(dt)
+	}
+
 	// Update thrown tools
 	alive := g.ThrownTools[:0]
 	for i := range g.ThrownTools {
@@ -273,18 +343,44 @@ func (g *GameState) Update(dt float32) {
 		}
 	}
 
+	// Save the dungeon map now and then
+	if g.Dungeon != nil {
+		g.Dungeon.Update(dt)
+	}
+
 	// Update floating XP indicators
 	g.updateFloatingXPs(dt)
+	g.updateFloatingDiffs(dt)
+
+	// Level-up chests sorted out with other instances when saving
+	if g.Profile != nil {
+		switch g.Profile.takeChestChange() {
+		case chestGranted:
+			g.PendingLevelUp = true
+		case chestTaken:
+			g.PendingLevelUp = false
+		}
+	}
 
 	// Spawn treasure chest if pending and no active chest
 	if g.ActiveChest == nil {
 		if g.PendingLevelUp {
 			g.ActiveChest = NewLevelUpChest(g.Profile)
 			g.PendingLevelUp = false
+			g.highlight("level-up")
+			g.Profile.AddLandmark(LandmarkLevel, fmt.Sprintf("Level %d", g.Profile.Level), time.Time{})
 		} else if g.PendingBonusChest {
 			g.ActiveChest = NewBonusChest(g.Profile, g.BonusChestReason)
+			g.Profile.AddLandmark(LandmarkChest, g.BonusChestReason+" chest", time.Time{})
 			g.PendingBonusChest = false
 			g.BonusChestReason = ""
+		} else if g.PendingDrop != "" {
+			// Edits made while the drop's chest was open can ask for it again
+			if item := GetItemByID(g.PendingDrop); item != nil && !g.Profile.IsOwned(item.ID) {
+				g.ActiveChest = NewDropChest(*item)
+				g.Profile.AddLandmark(LandmarkChest, item.Name, time.Time{})
+			}
+			g.PendingDrop = ""
 		}
 	}
 
@@ -339,6 +435,36 @@ func (g *GameState) updateFloatingXPs(dt float32) {
 	g.FloatingXPs = alive
 }
 
+// SpawnFloatingDiff throws the size of an edit out of Claude's quill
+func (g *GameState) SpawnFloatingDiff(added, removed int) {
+	offsetX := float32(len(g.FloatingDiffs)%3) * 12 // Spread out if multiple
+	g.FloatingDiffs = append(g.FloatingDiffs, FloatingDiff{
+		Added:   added,
+		Removed: removed,
+		X:       float32(screenWidth/2+22) + offsetX,
+		Y:       118,
+		VY:      -45,
+		MaxLife: 1.4,
+	})
+}
+
+// updateFloatingDiffs arcs the edit numbers up and lets them fall back
+func (g *GameState) updateFloatingDiffs(dt float32) {
+	alive := g.FloatingDiffs[:0]
+	for i := range g.FloatingDiffs {
+		d := &g.FloatingDiffs[i]
+		d.Timer += dt
+		d.Y += d.VY * dt
+		d.VY += 50 * dt
+		d.X += 8 * dt
+
+		if d.Timer < d.MaxLife {
+			alive = append(alive, *d)
+		}
+	}
+	g.FloatingDiffs = alive
+}
+
 // Mini Claude animation frame counts: Spawn=8, Idle=8, Walk=8, Poof=6
 var miniFrameCounts = []int{8, 8, 8, 6}
 
@@ -468,6 +594,37 @@ func (g *GameState) updateFlyingEnemies(dt float32) {
 	g.FlyingEnemies = aliveEnemies
 }
 
+// SetUsage takes the latest usage window snapshot and enters or leaves the inn
+func (g *GameState) SetUsage(u UsageWindow) {
+	wasResting := g.Resting
+	g.Usage = u
+	g.Resting = u.Exhausted(time.Now())
+
+	if g.Resting && !wasResting {
+		g.QuestText = "Out of stamina - resting at the inn"
+		g.QuestTimer = 0
+		g.QuestFade = 0
+	} else if wasResting && !g.Resting {
+		g.QuestText = "Stamina restored - back on the road!"
+		g.QuestTimer = 0
+		g.QuestFade = 0
+	}
+}
+
+// updateCost re-estimates session cost and spawns a warning when a budget is crossed
+func (g *GameState) updateCost() {
+	g.SessionCost = g.Config.CostOf(g.Session.ModelTokens).Total()
+	today := time.Now().Format("2006-01-02")
+	dailyCost := g.Config.CostOf(g.Profile.ModelTokensByDay[today]).Total()
+
+	for _, warning := range g.Budgets.Check(g.Config, g.SessionCost, dailyCost) {
+		g.SpawnEnemy(EnemyOverBudget)
+		g.QuestText = warning
+		g.QuestTimer = 0
+		g.QuestFade = 0
+	}
+}
+
 // SpawnEnemy creates a flying enemy that attacks Claude
 func (g *GameState) SpawnEnemy(enemyType EnemyType) {
 	// Start from right side of screen at varied heights
@@ -618,6 +775,9 @@ const (
 
 // HandleEvent updates game state based on events
 func (g *GameState) HandleEvent(event Event) {
+	// Text that ends up on screen comes from the scrubbed copy
+	shown := g.Privacy.Event(event)
+
 	// Mark activity for any real event (not idle)
 	if event.Type != EventIdle {
 		g.LastActivityTime = 0
@@ -631,6 +791,7 @@ func (g *GameState) HandleEvent(event Event) {
 				g.Session.FlowMeter = 1.0
 				if !g.Session.FlowPeakReached {
 					g.Session.FlowPeakReached = true
+					g.highlight("flow-peak")
 					// Grant XP for flow peak
 					if g.Profile.RecordFlowPeak() {
 						g.PendingLevelUp = true
@@ -642,88 +803,46 @@ func (g *GameState) HandleEvent(event Event) {
 		}
 	}
 
+	// Map the project as Claude explores it
+	if event.Cwd != "" && (g.Dungeon == nil || g.Dungeon.Project != event.Cwd) {
+		if g.Dungeon != nil {
+			g.Dungeon.Save()
+		}
+		g.Dungeon = LoadDungeon(event.Cwd, g.Profile == nil || g.Profile.scratch)
+	}
+	if g.Dungeon != nil {
+		g.Dungeon.HandleEvent(event)
+	}
+
 	// Update mana from token usage
 	if event.TokenUsage != nil {
 		g.ManaTotal = event.TokenUsage.Total()
-		if g.Profile != nil {
-			g.Profile.RecordTokens(event.TokenUsage.Total())
-		}
+	}
+	// The forecast and cache stats count each message's own usage once
+	if event.MessageUsage != nil && g.Forecast.Observe(event.MessageID, event.MessageUsage.Total()) {
+		g.Cache.Record(event.MessageUsage)
+		g.checkForecast()
 	}
 
 	// Track progression based on event type
 	if g.Profile != nil {
-		leveledUp := false
-
-		switch event.Type {
-		case EventReading:
-			g.Session.Reads++
-			leveledUp = g.Profile.RecordRead()
-			g.SpawnFloatingXP(XPRead)
-
-		case EventWriting:
-			g.Session.Writes++
-			leveledUp = g.Profile.RecordWrite()
-			g.SpawnFloatingXP(XPWrite)
-
-		case EventBash:
-			success := !event.IsError
-			g.Session.RecordBashResult(success)
-			leveledUp = g.Profile.RecordBash(success, g.Session.CurrentBashStreak)
-			if success {
-				xp := XPBashSuccess
-				if g.Session.CurrentBashStreak > 1 {
-					xp += XPStreakBonus
-				}
-				g.SpawnFloatingXP(xp)
-			} else {
-				g.SpawnFloatingXP(XPBashFail)
-			}
-
-		case EventThinkHard:
-			leveledUp = g.Profile.RecordThinking(event.ThinkLevel)
-			xp := XPThinkNormal
-			switch event.ThinkLevel {
-			case ThinkHard:
-				xp = XPThinkHard
-			case ThinkHarder:
-				xp = XPThinkHard + XPThinkBonus
-			case ThinkUltra:
-				xp = XPThinkHard + XPThinkBonus*2
-			}
+		result := applyProgression(g.Profile, &g.Session, g.Todos, event)
+		for _, xp := range result.XP {
 			g.SpawnFloatingXP(xp)
-
-		case EventAgentComplete:
-			leveledUp = g.Profile.RecordAgentComplete()
-			g.SpawnFloatingXP(XPAgentComplete)
-
-		case EventTodoUpdate:
-			// Count newly completed todos
-			if event.TodoItems != nil {
-				for _, todo := range event.TodoItems {
-					if todo.Status == "completed" {
-						// Check if this is a new completion
-						wasCompleted := false
-						for _, oldTodo := range g.Todos {
-							if oldTodo.Content == todo.Content && oldTodo.Status == "completed" {
-								wasCompleted = true
-								break
-							}
-						}
-						if !wasCompleted {
-							g.Session.TodosCompleted++
-							if g.Profile.RecordTodoComplete() {
-								leveledUp = true
-							}
-							g.SpawnFloatingXP(XPTodoComplete)
-						}
-					}
-				}
-			}
 		}
 
-		if leveledUp {
+		if result.LeveledUp {
 			g.PendingLevelUp = true
 		}
+		if result.Drop != "" {
+			g.PendingDrop = result.Drop
+		}
+		if event.LinesAdded+event.LinesRemoved > 0 {
+			g.SpawnFloatingDiff(event.LinesAdded, event.LinesRemoved)
+		}
+		if event.Type == EventBash && g.Session.CurrentBashStreak == clipBashStreak {
+			g.highlight("bash-streak")
+		}
 
 		// Check for bonus chest triggers
 		if !g.Session.BonusChestAwarded {
@@ -736,9 +855,19 @@ func (g *GameState) HandleEvent(event Event) {
 
 		// Save profile after changes
 		g.Profile.Save()
+
+		if event.MessageUsage != nil {
+			g.updateCost()
+		}
+	}
+
+	// Remember the stack Claude is working in
+	lang, hasLang := languageOf(event.Path)
+	if hasLang {
+		g.Language = lang
 	}
 
-	// Throw tool name for tool events
+	// Throw tool name for tool events, tinted by the file's language
 	if event.ToolName != "" {
 		var color uint32
 		switch event.Type {
@@ -757,26 +886,29 @@ func (g *GameState) HandleEvent(event Event) {
 		default:
 			color = colorDefault
 		}
-		g.ThrowTool(event.ToolName, color)
+		if hasLang {
+			color = lang.Color
+		}
+		g.ThrowTool(shown.ToolName, color)
 	}
 
 	// Handle specific event types
 	switch event.Type {
 	case EventQuest:
-		g.QuestText = event.Details
+		g.QuestText = shown.Details
 		g.QuestTimer = 0
 		g.QuestFade = 0
 
 	case EventThinking:
 		// Display thought in thought bubble if we have content
-		if event.ThoughtText != "" {
-			g.ThoughtText = event.ThoughtText
+		if shown.ThoughtText != "" {
+			g.ThoughtText = shown.ThoughtText
 			g.ThoughtTimer = 0
 			g.ThoughtFade = 0
 		}
 
 	case EventThinkHard:
-		g.QuestText = event.Details
+		g.QuestText = shown.Details
 		g.QuestTimer = 0
 		g.ThinkHardActive = true
 		g.ThinkHardTimer = 0
@@ -788,6 +920,12 @@ func (g *GameState) HandleEvent(event Event) {
 	case EventCompact:
 		g.CompactActive = true
 		g.CompactTimer = 0
+		// Summarize what was reclaimed once the next turn reports the new size
+		g.CompactPreTokens = g.ManaTotal
+		if event.CompactInfo != nil && event.CompactInfo.PreTokens > 0 {
+			g.CompactPreTokens = event.CompactInfo.PreTokens
+		}
+		g.Forecast.Compacted()
 		// Reset mana after compact
 		g.ManaTotal = 0
 
@@ -798,7 +936,7 @@ func (g *GameState) HandleEvent(event Event) {
 
 	case EventSpawnAgent:
 		// Extract agent type from details (format: "Agent: typename")
-		agentType := event.Details
+		agentType := shown.Details
 		if len(agentType) > 7 && agentType[:7] == "Agent: " {
 			agentType = agentType[7:]
 		}
@@ -809,26 +947,54 @@ func (g *GameState) HandleEvent(event Event) {
 		g.PoofMiniAgent("")
 
 	case EventError:
-		// Spawn a bug or ERROR enemy
+		// Spawn a bug or ERROR enemy, the nemesis of the language Claude is in
 		if randFloat() > 0.5 {
 			g.SpawnEnemy(EnemyBug)
 		} else {
 			g.SpawnEnemy(EnemyError)
 		}
+		g.FlyingEnemies[len(g.FlyingEnemies)-1].Language = g.Language
 
 	case EventGitPush:
 		// SHIPPED! - trigger epic rainbow banner effect
 		g.ShippedActive = true
 		g.ShippedTimer = 0
+		g.highlight("shipped")
+		if g.Profile != nil && g.Profile.RecordPush(event.Timestamp) {
+			g.Profile.Save()
+		}
 	}
 
-	// Check for low context - spawn LOW CTX enemy when below 20%
-	if g.ManaTotal > 0 {
-		usedRatio := float32(g.ManaTotal) / float32(g.ManaMax)
-		if usedRatio > 0.8 && randFloat() > 0.9 {
-			// Only spawn occasionally when context is very low
-			g.SpawnEnemy(EnemyLowContext)
+}
+
+// highlight flags a moment for the clip recorder, keeping the first one
+func (g *GameState) highlight(reason string) {
+	if g.PendingHighlight == "" {
+		g.PendingHighlight = reason
+	}
+}
+
+// checkForecast runs on each new turn: it reports what the last compaction
+// reclaimed and warns once when auto-compact is only a few turns away
+func (g *GameState) checkForecast() {
+	if g.CompactPreTokens > 0 {
+		reclaimed := g.CompactPreTokens - g.ManaTotal
+		if reclaimed > 0 {
+			g.QuestText = fmt.Sprintf("Compaction reclaimed %dk tokens (%dk -> %dk)",
+				reclaimed/1000, g.CompactPreTokens/1000, g.ManaTotal/1000)
+			g.QuestTimer = 0
+			g.QuestFade = 0
 		}
+		g.CompactPreTokens = 0
+	}
+
+	turns := g.Forecast.TurnsLeft(g.ManaTotal, g.ManaMax)
+	if turns >= 0 && turns < forecastWarnTurns && !g.Forecast.Warned {
+		g.Forecast.Warned = true
+		g.SpawnEnemy(EnemyLowContext)
+		g.QuestText = fmt.Sprintf("~%d turns until auto-compact", turns)
+		g.QuestTimer = 0
+		g.QuestFade = 0
 	}
 }
 
@@ -863,15 +1029,32 @@ Usage:
   cq replay <file>      Replay an existing conversation JSONL file
   cq studio             Studio mode - asset dev environment (requires -tags debug build)
   cq doctor             Check if Claude Quest can run properly
+  cq import             Import lifetime stats from past Claude Code transcripts
+  cq stats              Show lifetime stats and daily token usage
+  cq profile repair     Fix an inconsistent or unreadable career profile
+  cq profile restore    List profile backups (add a number to restore one)
+  cq serve [dir]        Serve a browser-source overlay for OBS (no window)
+  cq render <file>      Render a conversation to an animated GIF or PNG frames
+  cq tui [dir]          Play in the terminal with truecolor half blocks (no window)
 
 Options:
   -s, --speed <ms>      Replay speed in milliseconds (default: 200)
+  -n, --dry-run         Import: show what would be granted without saving
+  -y, --yes             Import: apply without asking for confirmation
+  -p, --port <port>     Serve: port to listen on (default: 7878)
+  --replay <file>       Serve, tui: replay a conversation instead of watching
+  -o, --output <path>   Render: .gif file, or a directory for PNG frames (default: out.gif)
+  --scale <n>           Render: upscale factor (default: 1)
+  --fps <n>             Render: frames per second (default: 20)
+  --from, --to <time>   Render: only capture this part of the replay (e.g. 10s, 1m)
+  --max <time>          Render: longest clip to capture (default: 30s)
   -h, --help            Show this help message
 
 Examples:
   cq                                    # Watch current project
   cq watch ~/Projects/myapp             # Watch specific project
   cq replay ~/.claude/projects/-Users-me-Projects-myapp/abc123.jsonl
+  cq render session.jsonl -o demo.gif --scale 2 --from 5s --max 20s
   go build -tags debug && ./cq studio   # Studio mode for asset development`)
 }
 
@@ -1198,6 +1381,30 @@ func main() {
 			runDoctor()
 			os.Exit(0)
 
+		case "import":
+			runImport(args[1:])
+			os.Exit(0)
+
+		case "profile":
+			runProfile(args[1:])
+			os.Exit(0)
+
+		case "stats":
+			runStats()
+			os.Exit(0)
+
+		case "serve":
+			runServe(args[1:])
+			os.Exit(0)
+
+		case "render":
+			runRender(args[1:])
+			os.Exit(0)
+
+		case "tui":
+			runTUI(args[1:])
+			os.Exit(0)
+
 		case "watch":
 			dir := "."
 			if len(args) > 1 {
@@ -1271,9 +1478,17 @@ func main() {
 	config := LoadConfig("config.json")
 	renderer := NewRenderer(config)
 	animations := NewAnimationSystem()
-	gameState := NewGameState()
+	gameState := NewGameState(config)
+	defer gameState.SaveDungeon()
 	renderer.SetProfile(gameState.Profile)
 
+	// Track the rolling usage window across every session in the background
+	usage := NewUsageTracker(time.Duration(config.UsageWindowHours*float64(time.Hour)), config.UsageWindowLimit)
+	usage.Start()
+
+	// Keep recent frames for highlight clips (opt-in)
+	clips := NewClipRecorder(config)
+
 	for !rl.WindowShouldClose() {
 		dt := rl.GetFrameTime()
 
@@ -1282,12 +1497,15 @@ func main() {
 		case event := <-watcher.Events:
 			animations.HandleEvent(event)
 			gameState.HandleEvent(event)
+			renderer.HandleEvent(event)
 		default:
 		}
 
 		// Update systems
+		gameState.SetUsage(usage.Snapshot())
 		animations.Update(dt)
 		gameState.Update(dt)
+		renderer.Update(dt)
 
 		// Sync activity state to animation system
 		animations.SetActive(gameState.IsActive)
@@ -1306,6 +1524,8 @@ func main() {
 		// Update picker animations
 		renderer.UpdatePickerAnim(dt)
 		renderer.UpdateModalPickerAnim(dt)
+		renderer.UpdateJourneyMapAnim(dt)
+		renderer.UpdateDungeonMapAnim(dt)
 
 		// Handle keyboard input
 		// Chest input takes priority when chest is active
@@ -1324,6 +1544,16 @@ func main() {
 			if rl.IsKeyPressed(rl.KeyEnter) || rl.IsKeyPressed(rl.KeySpace) {
 				gameState.ActiveChest.SkipToReveal()
 			}
+		} else if renderer.IsJourneyMapOpen() {
+			// Close the map with M or Escape
+			if rl.IsKeyPressed(rl.KeyM) || rl.IsKeyPressed(rl.KeyEscape) {
+				renderer.ToggleJourneyMap()
+			}
+		} else if renderer.IsDungeonMapOpen() {
+			// Close the map with D or Escape
+			if rl.IsKeyPressed(rl.KeyD) || rl.IsKeyPressed(rl.KeyEscape) {
+				renderer.ToggleDungeonMap()
+			}
 		} else if renderer.IsModalPickerOpen() {
 			// Strip picker input: Left/Right = switch slot, Up/Down = cycle item
 			if rl.IsKeyPressed(rl.KeyLeft) {
@@ -1343,22 +1573,34 @@ func main() {
 				renderer.ToggleModalPicker()
 			}
 		} else {
-			// Normal input: Tab opens picker
+			// Normal input: Tab opens picker, M the world map, D the dungeon map
 			if rl.IsKeyPressed(rl.KeyTab) {
 				renderer.ToggleModalPicker()
+			} else if rl.IsKeyPressed(rl.KeyM) {
+				renderer.ToggleJourneyMap()
+			} else if rl.IsKeyPressed(rl.KeyD) {
+				renderer.ToggleDungeonMap()
 			}
 		}
 
 		// Render to texture at native resolution
 		rl.BeginTextureMode(target)
-		rl.ClearBackground(rl.Color{R: 24, G: 20, B: 37, A: 255}) // Dark purple bg
+		rl.ClearBackground(sceneBackground)
 		renderer.Draw(animations.GetState())
 		renderer.DrawGameUI(gameState)
 		renderer.DrawAccessoryPickerHint() // Small hint at bottom
 		renderer.DrawTreasureChest(gameState)
 		renderer.DrawModalPicker() // Modal overlay on top
+		renderer.DrawJourneyMap()
+		renderer.DrawDungeonMap(gameState.Dungeon)
 		rl.EndTextureMode()
 
+		if gameState.PendingHighlight != "" {
+			clips.Trigger(gameState.PendingHighlight)
+			gameState.PendingHighlight = ""
+		}
+		clips.Update(dt, target)
+
 		// Draw scaled texture to window
 		rl.BeginDrawing()
 		rl.ClearBackground(rl.Black)
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ============================================================================
+// STREAMER PRIVACY
+// ============================================================================
+//
+// Quest text, thoughts and thrown tool names come straight from the
+// transcript, which can hold file paths, hostnames and whatever secrets
+// Claude happened to read. Every text field is run through the filter before
+// it reaches GameState (and the overlay).
+
+// Privacy modes (config "privacy")
+const (
+	PrivacyOff        = "off"        // Show transcript text as-is
+	PrivacyRedact     = "redact"     // Mask secrets, emails and hosts; shorten paths
+	PrivacyCategories = "categories" // Hide all free text, show only what kind of action it was
+)
+
+// secretPatterns are replaced in order, so specific formats go before generic ones
+var secretPatterns = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?(?:-----END [A-Z ]*PRIVATE KEY-----|$)`), "[private key]"},
+	{regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{8,}`), "[secret]"},        // Anthropic
+	{regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9_-]{16,}`), "[secret]"}, // OpenAI
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{12,}`), "[secret]"},      // AWS access key
+	{regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{16,}`), "[secret]"},      // GitHub
+	{regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{16,}`), "[secret]"},
+	{regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{16,}`), "[secret]"},              // GitLab
+	{regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{8,}`), "[secret]"},           // Slack
+	{regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{20,}`), "[secret]"},                // Google
+	{regexp.MustCompile(`\b[rsp]k_(?:live|test)_[0-9A-Za-z]{12,}`), "[secret]"}, // Stripe
+	{regexp.MustCompile(`\bnpm_[A-Za-z0-9]{20,}`), "[secret]"},
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]*`), "[token]"}, // JWT
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/-]{12,}=*`), "$1 [token]"},
+	{regexp.MustCompile(`(?i)\b([a-z0-9_-]*(?:api[_-]?key|secret|token|passw(?:or)?d|pwd|credential)[a-z0-9_-]*)(["']?\s*[:=]\s*["']?)[^\s"',;]{4,}`), "$1$2[secret]"},
+	{regexp.MustCompile(`://[^/\s:@]+:[^/\s@]+@`), "://[secret]@"}, // Credentials in URLs
+	{regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`), "[email]"},
+	{regexp.MustCompile(`(?i)\b(https?|wss?|ftp|ssh)://[^/\s"'<>]+`), "$1://[host]"},
+	{regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}(?::\d+)?\b`), "[ip]"},
+	{regexp.MustCompile(`(?i)\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:internal|local|lan|corp|intranet|home\.arpa)\b`), "[host]"},
+}
+
+// absPathPattern matches an absolute Unix or Windows path at the start of a word
+var absPathPattern = regexp.MustCompile(`(^|[\s"'(=\x60])((?:[A-Za-z]:\\|/)[^\s"'()<>\x60]+)`)
+
+// PrivacyFilter scrubs transcript text before it is shown
+type PrivacyFilter struct {
+	Mode string
+	Root string // Project root, learned from the transcript's cwd
+}
+
+// NewPrivacyFilter creates a filter for a privacy mode ("" means off)
+func NewPrivacyFilter(mode string) *PrivacyFilter {
+	if mode == "" {
+		mode = PrivacyOff
+	}
+	return &PrivacyFilter{Mode: mode}
+}
+
+// Text scrubs one piece of free text
+func (p *PrivacyFilter) Text(s string) string {
+	if s == "" {
+		return s
+	}
+	switch p.Mode {
+	case PrivacyRedact:
+		for _, secret := range secretPatterns {
+			s = secret.pattern.ReplaceAllString(s, secret.replace)
+		}
+		return p.anonymizePaths(s)
+	case PrivacyCategories:
+		return ""
+	}
+	return s
+}
+
+// anonymizePaths makes paths inside the project relative to its root and
+// cuts every other absolute path down to its file name
+func (p *PrivacyFilter) anonymizePaths(s string) string {
+	return absPathPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := absPathPattern.FindStringSubmatch(match)
+		prefix, path := m[1], m[2]
+
+		if p.Root != "" {
+			if rel, err := filepath.Rel(p.Root, path); err == nil && !strings.HasPrefix(rel, "..") {
+				if rel == "." {
+					return prefix + "."
+				}
+				return prefix + filepath.ToSlash(rel)
+			}
+		}
+		base := filepath.Base(strings.ReplaceAll(path, "\\", "/"))
+		if base == "/" || base == "." {
+			return prefix + "..."
+		}
+		return prefix + ".../" + base
+	})
+}
+
+// Event returns a copy of an event with its display text scrubbed. Game
+// mechanics should keep using the original event.
+func (p *PrivacyFilter) Event(e Event) Event {
+	if e.Cwd != "" {
+		p.Root = e.Cwd
+	}
+	if p.Mode == PrivacyOff || p.Mode == "" {
+		return e
+	}
+
+	if p.Mode == PrivacyCategories {
+		e.Details = eventCategory(e)
+		e.ThoughtText = ""
+		if e.ToolName != "" {
+			e.ToolName = toolCategory(e)
+		}
+		if e.Commit != "" {
+			e.Commit = "Commit"
+		}
+		return e
+	}
+
+	e.Details = p.Text(e.Details)
+	e.ThoughtText = p.Text(e.ThoughtText)
+	e.Commit = p.Text(e.Commit)
+	// MCP tools are named after the server, which can be an internal name
+	if strings.HasPrefix(e.ToolName, "mcp__") {
+		e.ToolName = "MCP"
+	}
+	return e
+}
+
+// eventCategory is the only text shown for an event in categories mode
+func eventCategory(e Event) string {
+	switch e.Type {
+	case EventQuest:
+		return "New quest"
+	case EventThinking:
+		return "Thinking"
+	case EventThinkHard:
+		return "Deep thinking..."
+	case EventReading:
+		return "Reading"
+	case EventBash:
+		return "Running command"
+	case EventWriting:
+		return "Writing code"
+	case EventSpawnAgent:
+		return "Agent: agent"
+	case EventError:
+		return "Error"
+	case EventSuccess:
+		return "Success"
+	case EventGitPush:
+		return "SHIPPED!"
+	case EventCompact:
+		return "Compacting"
+	case EventTodoUpdate:
+		return "Updating tasks"
+	case EventAskUser:
+		return "Asking question"
+	}
+	return ""
+}
+
+// toolCategory names the kind of tool instead of the tool itself
+func toolCategory(e Event) string {
+	switch e.Type {
+	case EventBash:
+		return "BASH"
+	case EventReading:
+		if e.ToolName == "WebSearch" || e.ToolName == "WebFetch" {
+			return "WEB"
+		}
+		return "READ"
+	case EventWriting:
+		return "WRITE"
+	case EventSpawnAgent:
+		return "AGENT"
+	}
+	return "TOOL"
+}
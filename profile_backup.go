@@ -0,0 +1,400 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// PROFILE BACKUPS & RECOVERY
+// ============================================================================
+//
+// A career profile is months of play, so it is never silently thrown away.
+// Good snapshots rotate through ~/.claude-quest-backups, and a file that can't
+// be parsed is renamed aside (quarantined) instead of being overwritten.
+
+const (
+	profileBackupKeep     = 10        // Snapshots kept before the oldest is pruned
+	profileBackupInterval = time.Hour // Minimum time between automatic snapshots
+	profileBackupStamp    = "20060102-150405.000"
+)
+
+// ProfileBackup is one snapshot in the backup directory
+type ProfileBackup struct {
+	Path string
+	Time time.Time // From the file name
+}
+
+// Load reads the snapshot, or returns nil if it's unreadable
+func (b ProfileBackup) Load() *CareerProfile {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return nil
+	}
+	profile, _ := decodeProfile(data)
+	return profile
+}
+
+// getProfileBackupDir returns the directory holding profile snapshots
+func getProfileBackupDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".claude-quest-backups"
+	}
+	return filepath.Join(home, ".claude-quest-backups")
+}
+
+// listProfileBackups returns all snapshots, newest first. They are only
+// read when loaded.
+func listProfileBackups() []ProfileBackup {
+	entries, err := os.ReadDir(getProfileBackupDir())
+	if err != nil {
+		return nil
+	}
+
+	var backups []ProfileBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "profile-") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, "profile-"), ".json")
+		ts, err := time.ParseInLocation(profileBackupStamp, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, ProfileBackup{Path: filepath.Join(getProfileBackupDir(), name), Time: ts})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups
+}
+
+// backupProfile copies the current profile file into the backup directory
+// and prunes old snapshots. Must be called with the profile lock held.
+func backupProfile() error {
+	dir := getProfileBackupDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	dest := filepath.Join(dir, "profile-"+time.Now().Format(profileBackupStamp)+".json")
+	if err := copyFile(getProfilePath(), dest); err != nil {
+		return err
+	}
+
+	backups := listProfileBackups()
+	for i := profileBackupKeep; i < len(backups); i++ {
+		os.Remove(backups[i].Path)
+	}
+	return nil
+}
+
+// backupProfileIfDue snapshots the profile if the newest backup is old enough.
+// Best effort - a failed backup never blocks a save.
+func backupProfileIfDue() {
+	backups := listProfileBackups()
+	if len(backups) > 0 && time.Since(backups[0].Time) < profileBackupInterval {
+		return
+	}
+	backupProfile()
+}
+
+// quarantineProfile moves an unreadable profile aside and returns its new path
+func quarantineProfile() (string, error) {
+	profilePath := getProfilePath()
+	dest := profilePath + ".corrupt-" + time.Now().Format(profileBackupStamp)
+	if err := os.Rename(profilePath, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// restoreLatestBackup copies the newest readable snapshot over the profile.
+// Must be called with the profile lock held. Returns nil if there is none.
+func restoreLatestBackup() *CareerProfile {
+	for _, backup := range listProfileBackups() {
+		profile := backup.Load()
+		if profile == nil {
+			continue
+		}
+		if err := copyFile(backup.Path, getProfilePath()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to restore %s: %v\n", backup.Path, err)
+			return nil
+		}
+		fmt.Printf("Restored profile from backup %s\n", backup.Path)
+		return profile
+	}
+	return nil
+}
+
+// recoverProfile handles an unreadable profile at startup: it quarantines the
+// file and falls back to the newest good backup (nil if there is none)
+func recoverProfile(readErr error) *CareerProfile {
+	var profile *CareerProfile
+	withProfileLock(func() error {
+		// Another instance may have recovered it while we waited for the lock
+		if p, err := readProfileFile(); err == nil {
+			profile = p
+			return nil
+		} else if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		quarantined, err := quarantineProfile()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: profile unreadable (%v) and could not be moved aside: %v\n", readErr, err)
+			return err
+		}
+		fmt.Printf("Profile unreadable (%v), moved to %s\n", readErr, quarantined)
+
+		profile = restoreLatestBackup()
+		if profile == nil {
+			fmt.Println("No usable backup found - starting a new profile")
+		}
+		return nil
+	})
+	return profile
+}
+
+// copyFile copies src to dst atomically (temp file + rename)
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	tempPath := dst + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, dst)
+}
+
+// repair fixes inconsistent values in a profile and describes each fix
+func (p *CareerProfile) repair() []string {
+	var fixes []string
+
+	counters := []struct {
+		name string
+		val  *int
+	}{
+		{"xp", &p.XP},
+		{"total_reads", &p.TotalReads},
+		{"total_writes", &p.TotalWrites},
+		{"total_bash", &p.TotalBash},
+		{"bash_successes", &p.BashSuccesses},
+		{"todos_completed", &p.TodosCompleted},
+		{"agents_completed", &p.AgentsCompleted},
+		{"sessions_started", &p.SessionsStarted},
+		{"peak_flow_count", &p.PeakFlowCount},
+		{"best_bash_streak", &p.BestBashStreak},
+		{"bonus_chests_found", &p.BonusChestsFound},
+	}
+	for _, c := range counters {
+		if *c.val < 0 {
+			fixes = append(fixes, fmt.Sprintf("%s was negative (%d), reset to 0", c.name, *c.val))
+			*c.val = 0
+		}
+	}
+	if t := p.Tokens; t.Input < 0 || t.CacheCreation < 0 || t.CacheRead < 0 || t.Output < 0 {
+		fixes = append(fixes, "lifetime token totals were negative, rebuilt from daily totals")
+		p.Tokens = TokenTotals{}
+		for _, totals := range p.TokensByDay {
+			p.Tokens.Add(totals)
+		}
+	}
+	for level, count := range p.TotalThinking {
+		if count < 0 {
+			fixes = append(fixes, fmt.Sprintf("total_thinking[%s] was negative (%d), reset to 0", level, count))
+			p.TotalThinking[level] = 0
+		}
+	}
+	for lang, stats := range p.Languages {
+		if stats.Reads < 0 || stats.Edits < 0 || stats.Lines < 0 {
+			fixes = append(fixes, fmt.Sprintf("languages[%s] had negative counts, reset to 0", lang))
+			p.Languages[lang] = LanguageStats{}
+		}
+	}
+	if p.BashSuccesses > p.TotalBash {
+		fixes = append(fixes, fmt.Sprintf("bash_successes (%d) exceeded total_bash, capped to %d", p.BashSuccesses, p.TotalBash))
+		p.BashSuccesses = p.TotalBash
+	}
+
+	if level := LevelFromXP(p.XP); p.Level != level {
+		fixes = append(fixes, fmt.Sprintf("level %d didn't match %d XP, set to %d", p.Level, p.XP, level))
+		p.Level = level
+	}
+	if p.ChestLevel > p.Level {
+		fixes = append(fixes, fmt.Sprintf("chest_level %d was above level %d, lowered", p.ChestLevel, p.Level))
+		p.ChestLevel = p.Level
+	}
+
+	for _, item := range ItemRegistry {
+		if item.Starter && !p.OwnedItems[item.ID] {
+			fixes = append(fixes, fmt.Sprintf("starter item %s was missing", item.ID))
+			p.OwnedItems[item.ID] = true
+		}
+	}
+
+	if p.FirstSeen.IsZero() || (!p.LastSeen.IsZero() && p.FirstSeen.After(p.LastSeen)) {
+		fixes = append(fixes, "first_seen was missing or after last_seen")
+		p.FirstSeen = earliestTime(p.LastSeen, time.Now())
+	}
+
+	return fixes
+}
+
+// runProfile handles `cq profile repair|restore`
+func runProfile(args []string) {
+	if len(args) == 0 {
+		printProfileUsage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch args[0] {
+	case "repair":
+		err = withProfileLock(repairProfile)
+	case "restore":
+		if len(args) < 2 {
+			printProfileBackups()
+			return
+		}
+		err = withProfileLock(func() error {
+			return restoreProfile(args[1])
+		})
+	default:
+		printProfileUsage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printProfileUsage prints help for the profile subcommands
+func printProfileUsage() {
+	fmt.Println(`Usage:
+  cq profile repair           Fix an inconsistent or unreadable profile
+  cq profile restore          List profile backups
+  cq profile restore <n>      Restore backup number n (or a backup file path)`)
+}
+
+// printProfileBackups lists the available snapshots
+func printProfileBackups() {
+	backups := listProfileBackups()
+	if len(backups) == 0 {
+		fmt.Printf("No backups in %s\n", getProfileBackupDir())
+		return
+	}
+
+	fmt.Printf("Backups in %s:\n\n", getProfileBackupDir())
+	for i, backup := range backups {
+		profile := backup.Load()
+		if profile == nil {
+			fmt.Printf("  %2d  %s  (unreadable)\n", i+1, backup.Time.Format("2006-01-02 15:04"))
+			continue
+		}
+		fmt.Printf("  %2d  %s  Level %d, %d XP\n", i+1, backup.Time.Format("2006-01-02 15:04"),
+			profile.Level, profile.XP)
+	}
+	fmt.Println("\nRestore one with: cq profile restore <n>")
+}
+
+// repairProfile quarantines an unreadable profile (restoring the newest good
+// backup) or fixes inconsistent values in a readable one
+func repairProfile() error {
+	profile, err := readProfileFile()
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("No profile at %s - nothing to repair\n", getProfilePath())
+		return nil
+	}
+	if err == nil && profile.SchemaVersion > profileSchemaVersion {
+		return errProfileTooNew
+	}
+	if err != nil {
+		quarantined, qerr := quarantineProfile()
+		if qerr != nil {
+			return qerr
+		}
+		fmt.Printf("Profile unreadable (%v), moved to %s\n", err, quarantined)
+		if restoreLatestBackup() == nil {
+			fmt.Println("No usable backup found - a new profile will be created on next run")
+		}
+		return nil
+	}
+
+	fixes := profile.repair()
+	if len(fixes) == 0 {
+		fmt.Println("Profile looks healthy - nothing to repair")
+		return nil
+	}
+
+	if err := backupProfile(); err != nil {
+		return fmt.Errorf("failed to back up profile before repair: %w", err)
+	}
+	profile.SchemaVersion = profileSchemaVersion
+	if err := writeProfileFile(profile); err != nil {
+		return err
+	}
+	for _, fix := range fixes {
+		fmt.Printf("  Fixed: %s\n", fix)
+	}
+	fmt.Printf("Repaired %d issue(s)\n", len(fixes))
+	return nil
+}
+
+// restoreProfile replaces the profile with a backup, chosen by its number in
+// the list or by path. The current profile is backed up first if readable.
+// Must be called with the profile lock held.
+func restoreProfile(which string) error {
+	path := which
+	if n, err := strconv.Atoi(which); err == nil {
+		backups := listProfileBackups()
+		if n < 1 || n > len(backups) {
+			return fmt.Errorf("no backup number %d (see cq profile restore)", n)
+		}
+		path = backups[n-1].Path
+	}
+
+	// Read the backup before taking the safety snapshot, which may prune it
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("backup %s is unreadable: %w", path, err)
+	}
+	profile, err := decodeProfile(data)
+	if err != nil {
+		return fmt.Errorf("backup %s is unreadable: %w", path, err)
+	}
+
+	if _, err := readProfileFile(); err == nil {
+		if err := backupProfile(); err != nil {
+			return fmt.Errorf("failed to back up current profile: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		if _, err := quarantineProfile(); err != nil {
+			return err
+		}
+	}
+
+	tempPath := getProfilePath() + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, getProfilePath()); err != nil {
+		return err
+	}
+	fmt.Printf("Restored profile from %s (Level %d, %d XP)\n", path, profile.Level, profile.XP)
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// profileSchemaVersion is the current career profile format.
+// Bump it and append a migration whenever a field changes meaning or shape.
+const profileSchemaVersion = 3
+
+// errProfileTooNew keeps this build from writing over a profile from a newer
+// one, which would drop the fields it doesn't know
+var errProfileTooNew = errors.New("profile was written by a newer cq")
+
+// newerProfileWarning makes sure the newer-schema warning is only shown once,
+// however often the profile is read
+var newerProfileWarning sync.Once
+
+// profileMigrations upgrade raw profile JSON one version at a time:
+// profileMigrations[n] turns a version n profile into version n+1.
+// They work on the raw map so renamed or retyped fields can be carried over.
+var profileMigrations = []func(raw map[string]interface{}){
+	// 0 -> 1: profiles written before schema versioning. Older builds
+	// didn't always write the item and thinking maps.
+	func(raw map[string]interface{}) {
+		if _, ok := raw["owned_items"].(map[string]interface{}); !ok {
+			raw["owned_items"] = map[string]interface{}{}
+		}
+		if _, ok := raw["total_thinking"].(map[string]interface{}); !ok {
+			raw["total_thinking"] = map[string]interface{}{}
+		}
+	},
+	// 1 -> 2: tokens_consumed summed the whole context size on every event,
+	// so it can't be converted. Per-message accounting starts from zero.
+	func(raw map[string]interface{}) {
+		delete(raw, "tokens_consumed")
+		raw["tokens"] = map[string]interface{}{}
+		raw["tokens_by_day"] = map[string]interface{}{}
+	},
+	// 2 -> 3: chest_level records which levels got a chest. Every level
+	// reached so far already had one.
+	func(raw map[string]interface{}) {
+		raw["chest_level"] = raw["level"]
+	},
+}
+
+// decodeProfile parses profile JSON of any known schema version,
+// migrating it to the current version
+func decodeProfile(data []byte) (*CareerProfile, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("profile is empty")
+	}
+
+	version := 0
+	if v, ok := raw["schema_version"].(float64); ok {
+		version = int(v)
+	}
+	if version > profileSchemaVersion {
+		// Written by a newer cq - load what we understand rather than discard it
+		newerProfileWarning.Do(func() {
+			fmt.Fprintf(os.Stderr, "Warning: profile schema v%d is newer than this build (v%d) - progress won't be saved\n",
+				version, profileSchemaVersion)
+		})
+	}
+
+	for v := version; v < profileSchemaVersion; v++ {
+		profileMigrations[v](raw)
+	}
+	if version < profileSchemaVersion {
+		raw["schema_version"] = profileSchemaVersion
+	}
+
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := newEmptyProfile()
+	if err := json.Unmarshal(migrated, profile); err != nil {
+		return nil, err
+	}
+	if profile.OwnedItems == nil {
+		profile.OwnedItems = make(map[string]bool)
+	}
+	if profile.TotalThinking == nil {
+		profile.TotalThinking = make(map[string]int)
+	}
+	if profile.TokensByDay == nil {
+		profile.TokensByDay = make(map[string]TokenTotals)
+	}
+	if profile.ModelTokensByDay == nil {
+		profile.ModelTokensByDay = make(map[string]map[string]TokenTotals)
+	}
+	if profile.Languages == nil {
+		profile.Languages = make(map[string]LanguageStats)
+	}
+	return profile, nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// ============================================================================
+// MULTI-INSTANCE PROFILE SYNC
+// ============================================================================
+//
+// Several cq windows (one per project) share one career profile. Each instance
+// remembers the snapshot it last read from or wrote to disk. On save it takes
+// an advisory lock, re-reads the file and applies only its own changes since
+// that snapshot, so no instance overwrites another's progress.
+
+// withProfileLock runs fn while holding the cross-process profile lock
+func withProfileLock(fn func() error) error {
+	return withFileLock(getProfilePath()+".lock", fn)
+}
+
+// withFileLock runs fn while holding an advisory lock on the given lock file
+func withFileLock(lockPath string, fn func() error) error {
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return err
+	}
+	defer unlockFile(f)
+
+	return fn()
+}
+
+// newEmptyProfile returns a profile with zeroed stats and initialized maps
+func newEmptyProfile() *CareerProfile {
+	return &CareerProfile{
+		OwnedItems:       make(map[string]bool),
+		TotalThinking:    make(map[string]int),
+		TokensByDay:      make(map[string]TokenTotals),
+		ModelTokensByDay: make(map[string]map[string]TokenTotals),
+		Languages:        make(map[string]LanguageStats),
+	}
+}
+
+// readProfileFile reads the profile currently on disk, migrated to the current
+// schema. The error wraps os.ErrNotExist when there is no profile yet.
+func readProfileFile() (*CareerProfile, error) {
+	data, err := os.ReadFile(getProfilePath())
+	if err != nil {
+		return nil, err
+	}
+	return decodeProfile(data)
+}
+
+// writeProfileFile writes a profile to disk atomically (temp file + rename)
+func writeProfileFile(p *CareerProfile) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	profilePath := getProfilePath()
+	tempPath := profilePath + ".tmp"
+
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, profilePath)
+}
+
+// clone returns a deep copy of the profile (without its sync snapshot)
+func (p *CareerProfile) clone() *CareerProfile {
+	c := *p
+	c.base = nil
+	c.OwnedItems = make(map[string]bool, len(p.OwnedItems))
+	for id, owned := range p.OwnedItems {
+		c.OwnedItems[id] = owned
+	}
+	c.TotalThinking = make(map[string]int, len(p.TotalThinking))
+	for level, count := range p.TotalThinking {
+		c.TotalThinking[level] = count
+	}
+	c.TokensByDay = make(map[string]TokenTotals, len(p.TokensByDay))
+	for day, totals := range p.TokensByDay {
+		c.TokensByDay[day] = totals
+	}
+	c.ModelTokensByDay = make(map[string]map[string]TokenTotals, len(p.ModelTokensByDay))
+	for day, models := range p.ModelTokensByDay {
+		c.ModelTokensByDay[day] = make(map[string]TokenTotals, len(models))
+		for model, totals := range models {
+			c.ModelTokensByDay[day][model] = totals
+		}
+	}
+	c.Languages = make(map[string]LanguageStats, len(p.Languages))
+	for lang, stats := range p.Languages {
+		c.Languages[lang] = stats
+	}
+	c.Journey.Landmarks = append([]Landmark(nil), p.Journey.Landmarks...)
+	return &c
+}
+
+// mergeProfile applies the changes mem made since base on top of disk.
+// Counters add their deltas, owned items are unioned, bests take the max.
+// Landmarks left since base are added to the journey on disk.
+func mergeProfile(disk, base, mem *CareerProfile) *CareerProfile {
+	m := disk.clone()
+
+	addDelta := func(dst *int, memVal, baseVal int) {
+		*dst += memVal - baseVal
+	}
+
+	addDelta(&m.XP, mem.XP, base.XP)
+	addDelta(&m.TotalReads, mem.TotalReads, base.TotalReads)
+	addDelta(&m.TotalWrites, mem.TotalWrites, base.TotalWrites)
+	addDelta(&m.TotalBash, mem.TotalBash, base.TotalBash)
+	addDelta(&m.BashSuccesses, mem.BashSuccesses, base.BashSuccesses)
+	addDelta(&m.TodosCompleted, mem.TodosCompleted, base.TodosCompleted)
+	addDelta(&m.AgentsCompleted, mem.AgentsCompleted, base.AgentsCompleted)
+	addDelta(&m.SessionsStarted, mem.SessionsStarted, base.SessionsStarted)
+	addDelta(&m.PeakFlowCount, mem.PeakFlowCount, base.PeakFlowCount)
+	addDelta(&m.BonusChestsFound, mem.BonusChestsFound, base.BonusChestsFound)
+	m.Tokens.Add(mem.Tokens.Sub(base.Tokens))
+	m.Journey.Distance += mem.Journey.Distance - base.Journey.Distance
+	m.Journey.Landmarks = mergeLandmarks(disk.Journey.Landmarks, base.Journey.Landmarks, mem.Journey.Landmarks)
+
+	for level, count := range mem.TotalThinking {
+		m.TotalThinking[level] += count - base.TotalThinking[level]
+	}
+	for day, totals := range mem.TokensByDay {
+		dayTotals := m.TokensByDay[day]
+		dayTotals.Add(totals.Sub(base.TokensByDay[day]))
+		m.TokensByDay[day] = dayTotals
+	}
+	for day, models := range mem.ModelTokensByDay {
+		if m.ModelTokensByDay[day] == nil {
+			m.ModelTokensByDay[day] = make(map[string]TokenTotals)
+		}
+		for model, totals := range models {
+			modelTotals := m.ModelTokensByDay[day][model]
+			modelTotals.Add(totals.Sub(base.ModelTokensByDay[day][model]))
+			m.ModelTokensByDay[day][model] = modelTotals
+		}
+	}
+	for lang, stats := range mem.Languages {
+		was := base.Languages[lang]
+		merged := m.Languages[lang]
+		addDelta(&merged.Reads, stats.Reads, was.Reads)
+		addDelta(&merged.Edits, stats.Edits, was.Edits)
+		addDelta(&merged.Lines, stats.Lines, was.Lines)
+		m.Languages[lang] = merged
+	}
+
+	// Items are never taken away, so ownership is a union
+	for id, owned := range mem.OwnedItems {
+		if owned {
+			m.OwnedItems[id] = true
+		}
+	}
+
+	if mem.BestBashStreak > m.BestBashStreak {
+		m.BestBashStreak = mem.BestBashStreak
+	}
+
+	// Flags: our change wins, otherwise keep what's on disk
+	if mem.PendingChoice != base.PendingChoice {
+		m.PendingChoice = mem.PendingChoice
+	}
+
+	// Each level gets one chest, whichever instance reaches it
+	m.Level = LevelFromXP(m.XP)
+	m.ChestLevel = max(disk.ChestLevel, mem.ChestLevel)
+	switch {
+	case mem.ChestLevel > base.ChestLevel && disk.ChestLevel >= mem.ChestLevel:
+		// Another instance got there first and already granted it
+		m.PendingChoice = disk.PendingChoice
+		m.chestChange = chestTaken
+	case m.Level > m.ChestLevel:
+		// Only the instances' XP together crossed it
+		m.ChestLevel = m.Level
+		m.PendingChoice = true
+		m.chestChange = chestGranted
+	}
+
+	m.FirstSeen = earliestTime(m.FirstSeen, mem.FirstSeen)
+	if mem.LastSeen.After(m.LastSeen) {
+		m.LastSeen = mem.LastSeen
+	}
+	return m
+}
+
+// earliestTime returns the earlier of two times, ignoring zero values
+func earliestTime(a, b time.Time) time.Time {
+	if a.IsZero() {
+		return b
+	}
+	if b.IsZero() || a.Before(b) {
+		return a
+	}
+	return b
+}
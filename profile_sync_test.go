@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestMergeLevelUps(t *testing.T) {
+	base := newEmptyProfile()
+	base.AddXP(350)
+	base.ClaimItem("party")
+	if base.Level != 1 || base.ChestLevel != 1 {
+		t.Fatalf("350 XP is level %d with chest level %d, want 1 and 1", base.Level, base.ChestLevel)
+	}
+
+	// Neither instance reaches level 2 alone, together they do
+	disk, mem := base.clone(), base.clone()
+	disk.AddXP(30)
+	mem.AddXP(20)
+	m := mergeProfile(disk, base, mem)
+	if m.Level != 2 || m.ChestLevel != 2 || !m.PendingChoice || m.chestChange != chestGranted {
+		t.Errorf("joint level-up merged to level %d, chest level %d, pending %v, change %d; want a chest for level 2",
+			m.Level, m.ChestLevel, m.PendingChoice, m.chestChange)
+	}
+
+	// Both reach level 2 alone: only the first to save keeps its chest
+	disk, mem = base.clone(), base.clone()
+	if !disk.AddXP(70) || !mem.AddXP(60) {
+		t.Fatal("AddXP didn't level up")
+	}
+	m = mergeProfile(disk, base, mem)
+	if m.ChestLevel != 2 || m.chestChange != chestTaken {
+		t.Errorf("double level-up merged to chest level %d, change %d; want level 2 taken", m.ChestLevel, m.chestChange)
+	}
+
+	// Reaching a level alone is already granted
+	disk, mem = base.clone(), base.clone()
+	mem.AddXP(60)
+	m = mergeProfile(disk, base, mem)
+	if m.ChestLevel != 2 || !m.PendingChoice || m.chestChange != chestUnchanged {
+		t.Errorf("own level-up merged to chest level %d, pending %v, change %d; want it kept as is",
+			m.ChestLevel, m.PendingChoice, m.chestChange)
+	}
+}
+
+func TestSaveKeepsNewerProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+	newer := []byte(`{"schema_version": 99, "xp": 500, "from_the_future": true}`)
+	if err := os.WriteFile(getProfilePath(), newer, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := LoadProfile()
+	p.AddXP(10)
+	if err := p.Save(); !errors.Is(err, errProfileTooNew) {
+		t.Errorf("Save over a newer profile returned %v, want errProfileTooNew", err)
+	}
+	if data, _ := os.ReadFile(getProfilePath()); !bytes.Equal(data, newer) {
+		t.Errorf("newer profile was rewritten: %s", data)
+	}
+}
@@ -1,7 +1,7 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -25,8 +25,9 @@ type Item struct {
 	ID       string
 	Name     string
 	Slot     ItemSlot
-	MinLevel int  // Level required to be in choice pool
-	Starter  bool // Auto-unlocked at level 1
+	MinLevel int    // Level required to be in choice pool
+	Starter  bool   // Auto-unlocked at level 1
+	Language string // Rare drop from edits in this language, never in the choice pool
 }
 
 // ItemRegistry contains all unlockable items
@@ -78,10 +79,16 @@ var ItemRegistry = []Item{
 	{ID: "trail_hearts", Name: "Heart Trail", Slot: SlotTrail, MinLevel: 33},
 	{ID: "trail_pixel", Name: "Pixel Trail", Slot: SlotTrail, MinLevel: 41},
 	{ID: "trail_rainbow", Name: "Rainbow Trail", Slot: SlotTrail, MinLevel: 50},
+
+	// ==================== RARE DROPS (by language) ====================
+	{ID: "aura_snake", Name: "Snake Charm", Slot: SlotAura, Language: "Python"},
+	{ID: "trail_gopher", Name: "Gopher Companion", Slot: SlotTrail, Language: "Go"},
 }
 
 // CareerProfile stores persistent progression data
 type CareerProfile struct {
+	SchemaVersion int `json:"schema_version"`
+
 	// XP & Level
 	XP    int `json:"xp"`
 	Level int `json:"level"`
@@ -89,6 +96,7 @@ type CareerProfile struct {
 	// Item ownership
 	OwnedItems    map[string]bool `json:"owned_items"`
 	PendingChoice bool            `json:"pending_choice"` // True if level-up choice awaits
+	ChestLevel    int             `json:"chest_level"`    // Highest level a level-up chest was granted for
 
 	// Lifetime stats
 	TotalReads      int            `json:"total_reads"`
@@ -98,19 +106,50 @@ type CareerProfile struct {
 	TotalThinking   map[string]int `json:"total_thinking"` // by level name
 	TodosCompleted  int            `json:"todos_completed"`
 	AgentsCompleted int            `json:"agents_completed"`
-	TokensConsumed  int64          `json:"tokens_consumed"`
 	SessionsStarted int            `json:"sessions_started"`
 
+	// Token usage, counted once per assistant message
+	Tokens      TokenTotals            `json:"tokens"`
+	TokensByDay map[string]TokenTotals `json:"tokens_by_day"` // by local date (2006-01-02)
+
+	// Same usage split by model per day, for cost estimates
+	ModelTokensByDay map[string]map[string]TokenTotals `json:"model_tokens_by_day"`
+
 	// Achievements
 	PeakFlowCount    int `json:"peak_flow_count"`
 	BestBashStreak   int `json:"best_bash_streak"`
 	BonusChestsFound int `json:"bonus_chests_found"`
 
+	// Reads, edits and lines by language name
+	Languages map[string]LanguageStats `json:"languages"`
+
+	// Distance walked and landmarks along the way
+	Journey Journey `json:"journey"`
+
 	// Timestamps
 	FirstSeen time.Time `json:"first_seen"`
 	LastSeen  time.Time `json:"last_seen"`
+
+	// Snapshot last read from or written to disk (for merging with other instances)
+	base *CareerProfile
+
+	// In-memory only (cq render): Save never touches the file
+	scratch bool
+
+	// What saving found out about level-up chests, until the game takes it
+	chestChange chestChange
 }
 
+// chestChange is how merging with other instances changed this instance's
+// level-up chests
+type chestChange int
+
+const (
+	chestUnchanged chestChange = iota
+	chestGranted               // XP from several instances crossed a level none granted
+	chestTaken                 // Another instance already granted the level this one did
+)
+
 // SessionStats tracks ephemeral per-session data
 type SessionStats struct {
 	// Activity counts
@@ -132,12 +171,51 @@ type SessionStats struct {
 
 	// Bonus chest
 	BonusChestAwarded bool
+
+	// Token usage this session, and the usage already credited per message ID
+	Tokens       TokenTotals
+	ModelTokens  map[string]TokenTotals // by model, for cost estimates
+	messageUsage map[string]TokenUsage
+	messageOrder []string // Message IDs in messageUsage, oldest first
+}
+
+// TokenTotals counts tokens by kind
+type TokenTotals struct {
+	Input         int64 `json:"input"`
+	CacheCreation int64 `json:"cache_creation"`
+	CacheRead     int64 `json:"cache_read"`
+	Output        int64 `json:"output"`
+}
+
+// Add adds another set of totals to this one
+func (t *TokenTotals) Add(o TokenTotals) {
+	t.Input += o.Input
+	t.CacheCreation += o.CacheCreation
+	t.CacheRead += o.CacheRead
+	t.Output += o.Output
+}
+
+// Sub returns the difference t - o
+func (t TokenTotals) Sub(o TokenTotals) TokenTotals {
+	return TokenTotals{
+		Input:         t.Input - o.Input,
+		CacheCreation: t.CacheCreation - o.CacheCreation,
+		CacheRead:     t.CacheRead - o.CacheRead,
+		Output:        t.Output - o.Output,
+	}
+}
+
+// Total returns all tokens of every kind
+func (t TokenTotals) Total() int64 {
+	return t.Input + t.CacheCreation + t.CacheRead + t.Output
 }
 
 // XP rewards per event type
 const (
 	XPRead          = 5
-	XPWrite         = 10
+	XPWrite         = 10 // Plus XPWriteLines per line changed, up to XPWriteMax
+	XPWriteLines    = 0.1
+	XPWriteMax      = 40
 	XPBashSuccess   = 15
 	XPBashFail      = 5
 	XPStreakBonus   = 5
@@ -160,33 +238,24 @@ func getProfilePath() string {
 
 // LoadProfile loads the career profile from disk, or creates a new one
 func LoadProfile() *CareerProfile {
-	profile := &CareerProfile{
-		OwnedItems:    make(map[string]bool),
-		TotalThinking: make(map[string]int),
-		FirstSeen:     time.Now(),
-		LastSeen:      time.Now(),
+	profile, err := readProfileFile()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// Unreadable - set it aside and fall back to the newest good backup
+		profile = recoverProfile(err)
 	}
-
-	data, err := os.ReadFile(getProfilePath())
-	if err != nil {
-		// New profile - grant starter items
+	if profile == nil {
+		// New profile - start fresh
+		profile = newEmptyProfile()
+		profile.SchemaVersion = profileSchemaVersion
+		profile.FirstSeen = time.Now()
+		profile.LastSeen = time.Now()
+		profile.base = newEmptyProfile()
 		profile.grantStarterItems()
 		return profile
 	}
 
-	if err := json.Unmarshal(data, profile); err != nil {
-		// Corrupted file - start fresh
-		profile.grantStarterItems()
-		return profile
-	}
-
-	// Ensure maps are initialized (in case of old profile format)
-	if profile.OwnedItems == nil {
-		profile.OwnedItems = make(map[string]bool)
-	}
-	if profile.TotalThinking == nil {
-		profile.TotalThinking = make(map[string]int)
-	}
+	// Remember what was on disk so Save only applies our own changes
+	profile.base = profile.clone()
 
 	// Ensure starter items are owned (migration for existing profiles)
 	profile.grantStarterItems()
@@ -203,23 +272,51 @@ func (p *CareerProfile) grantStarterItems() {
 	}
 }
 
-// Save writes the profile to disk atomically (temp file + rename)
+// Save merges this instance's changes into the profile on disk and writes it
+// atomically (temp file + rename), holding the profile lock so concurrent cq
+// windows never overwrite each other's progress
 func (p *CareerProfile) Save() error {
-	p.LastSeen = time.Now()
-
-	data, err := json.MarshalIndent(p, "", "  ")
-	if err != nil {
-		return err
+	if p.scratch {
+		return nil
 	}
+	p.LastSeen = time.Now()
 
-	profilePath := getProfilePath()
-	tempPath := profilePath + ".tmp"
+	return withProfileLock(func() error {
+		merged := p.clone()
+		disk, err := readProfileFile()
+		switch {
+		case err == nil:
+			if disk.SchemaVersion > profileSchemaVersion {
+				return errProfileTooNew
+			}
+			base := p.base
+			if base == nil {
+				base = newEmptyProfile()
+			}
+			merged = mergeProfile(disk, base, p)
+			// Snapshot the last good file before replacing it
+			backupProfileIfDue()
+		case !errors.Is(err, os.ErrNotExist):
+			// Never overwrite a file we couldn't read
+			if _, qerr := quarantineProfile(); qerr != nil {
+				return qerr
+			}
+		}
+		merged.SchemaVersion = profileSchemaVersion
+		if merged.chestChange == chestUnchanged {
+			// Keep a change the game hasn't taken yet
+			merged.chestChange = p.chestChange
+		}
 
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return err
-	}
+		if err := writeProfileFile(merged); err != nil {
+			return err
+		}
 
-	return os.Rename(tempPath, profilePath)
+		// Pick up progress from other instances
+		*p = *merged
+		p.base = merged.clone()
+		return nil
+	})
 }
 
 // XPForLevel returns the total XP required to reach a given level
@@ -261,23 +358,41 @@ func (p *CareerProfile) AddXP(amount int) bool {
 	p.XP += amount
 	p.Level = LevelFromXP(p.XP)
 
-	if p.Level > oldLevel {
+	if p.Level > oldLevel && p.Level > p.ChestLevel {
+		p.ChestLevel = p.Level
 		p.PendingChoice = true
 		return true
 	}
 	return false
 }
 
+// takeChestChange returns how the last saves changed this instance's
+// level-up chests, and forgets it
+func (p *CareerProfile) takeChestChange() chestChange {
+	change := p.chestChange
+	p.chestChange = chestUnchanged
+	return change
+}
+
 // RecordRead tracks a read operation and grants XP
 func (p *CareerProfile) RecordRead() bool {
 	p.TotalReads++
 	return p.AddXP(XPRead)
 }
 
-// RecordWrite tracks a write operation and grants XP
-func (p *CareerProfile) RecordWrite() bool {
+// WriteXP returns the XP for an edit that changes this many lines
+func WriteXP(lines int) int {
+	xp := XPWrite + int(float64(lines)*XPWriteLines)
+	if xp > XPWriteMax {
+		xp = XPWriteMax
+	}
+	return xp
+}
+
+// RecordWrite tracks a write operation and grants XP by its size
+func (p *CareerProfile) RecordWrite(lines int) bool {
 	p.TotalWrites++
-	return p.AddXP(XPWrite)
+	return p.AddXP(WriteXP(lines))
 }
 
 // RecordBash tracks a bash operation and grants XP
@@ -336,17 +451,141 @@ func (p *CareerProfile) RecordFlowPeak() bool {
 	return p.AddXP(XPFlowPeak)
 }
 
-// RecordTokens tracks token consumption
-func (p *CareerProfile) RecordTokens(count int) {
-	p.TokensConsumed += int64(count)
+// RecordTokens adds newly used tokens to the lifetime and per-day totals
+func (p *CareerProfile) RecordTokens(delta TokenTotals, model string, when time.Time) {
+	if delta == (TokenTotals{}) {
+		return
+	}
+	if when.IsZero() {
+		when = time.Now()
+	}
+	p.Tokens.Add(delta)
+
+	day := when.Local().Format("2006-01-02")
+	totals := p.TokensByDay[day]
+	totals.Add(delta)
+	p.TokensByDay[day] = totals
+
+	if model == "" {
+		return
+	}
+	if p.ModelTokensByDay[day] == nil {
+		p.ModelTokensByDay[day] = make(map[string]TokenTotals)
+	}
+	modelTotals := p.ModelTokensByDay[day][model]
+	modelTotals.Add(delta)
+	p.ModelTokensByDay[day][model] = modelTotals
+}
+
+// ModelTokens returns lifetime token totals by model
+func (p *CareerProfile) ModelTokens() map[string]TokenTotals {
+	byModel := make(map[string]TokenTotals)
+	for _, models := range p.ModelTokensByDay {
+		for model, t := range models {
+			totals := byModel[model]
+			totals.Add(t)
+			byModel[model] = totals
+		}
+	}
+	return byModel
+}
+
+// ProgressResult describes what a single event earned toward the career
+type ProgressResult struct {
+	XP        []int // XP grants in order (one floating indicator each)
+	LeveledUp bool
+	Drop      string // Rare language drop earned but not owned yet ("" = none)
+}
+
+// applyProgression records an event against the session and career stats.
+// prevTodos is the todo list before this event, used to spot new completions.
+// Live play and transcript import both go through here so they follow the same rules.
+func applyProgression(p *CareerProfile, s *SessionStats, prevTodos []TodoItem, event Event) ProgressResult {
+	var result ProgressResult
+
+	if event.MessageUsage != nil {
+		delta := s.RecordUsage(event.MessageID, event.Model, event.MessageUsage)
+		p.RecordTokens(delta, event.Model, event.Timestamp)
+	}
+
+	result.Drop = p.RecordLanguage(event)
+
+	switch event.Type {
+	case EventReading:
+		s.Reads++
+		result.LeveledUp = p.RecordRead()
+		result.XP = append(result.XP, XPRead)
+
+	case EventWriting:
+		s.Writes++
+		lines := event.LinesAdded + event.LinesRemoved
+		result.LeveledUp = p.RecordWrite(lines)
+		result.XP = append(result.XP, WriteXP(lines))
+
+	case EventBash:
+		success := !event.IsError
+		s.RecordBashResult(success)
+		result.LeveledUp = p.RecordBash(success, s.CurrentBashStreak)
+		if success {
+			xp := XPBashSuccess
+			if s.CurrentBashStreak > 1 {
+				xp += XPStreakBonus
+			}
+			result.XP = append(result.XP, xp)
+		} else {
+			result.XP = append(result.XP, XPBashFail)
+		}
+
+	case EventThinkHard:
+		result.LeveledUp = p.RecordThinking(event.ThinkLevel)
+		xp := XPThinkNormal
+		switch event.ThinkLevel {
+		case ThinkHard:
+			xp = XPThinkHard
+		case ThinkHarder:
+			xp = XPThinkHard + XPThinkBonus
+		case ThinkUltra:
+			xp = XPThinkHard + XPThinkBonus*2
+		}
+		result.XP = append(result.XP, xp)
+
+	case EventAgentComplete:
+		result.LeveledUp = p.RecordAgentComplete()
+		result.XP = append(result.XP, XPAgentComplete)
+
+	case EventTodoUpdate:
+		// Count newly completed todos
+		for _, todo := range event.TodoItems {
+			if todo.Status != "completed" {
+				continue
+			}
+			// Check if this is a new completion
+			wasCompleted := false
+			for _, oldTodo := range prevTodos {
+				if oldTodo.Content == todo.Content && oldTodo.Status == "completed" {
+					wasCompleted = true
+					break
+				}
+			}
+			if !wasCompleted {
+				s.TodosCompleted++
+				if p.RecordTodoComplete() {
+					result.LeveledUp = true
+				}
+				result.XP = append(result.XP, XPTodoComplete)
+			}
+		}
+	}
+
+	return result
 }
 
 // GetChoicePool returns items available for level-up choice
 func (p *CareerProfile) GetChoicePool() []Item {
 	var pool []Item
 	for _, item := range ItemRegistry {
-		// Item is in pool if: at/below player level AND not yet owned AND not starter
-		if !item.Starter && item.MinLevel <= p.Level && !p.OwnedItems[item.ID] {
+		// Item is in pool if: at/below player level AND not yet owned AND not starter or a drop
+		if !item.Starter && item.Language == "" && item.MinLevel <= p.Level && !p.OwnedItems[item.ID] {
 			pool = append(pool, item)
 		}
 	}
@@ -486,3 +725,52 @@ func (s *SessionStats) RecordBashResult(success bool) {
 		s.CurrentBashStreak = 0
 	}
 }
+
+// usageMessagesKept is how many recent message IDs RecordUsage remembers
+const usageMessagesKept = 64
+
+// RecordUsage credits a message's usage once and returns the newly used tokens.
+// Each content block of a message is logged with the message's usage so far,
+// so only growth beyond what was already credited for that ID counts.
+func (s *SessionStats) RecordUsage(messageID, model string, u *TokenUsage) TokenTotals {
+	if s.messageUsage == nil {
+		s.messageUsage = make(map[string]TokenUsage)
+		s.ModelTokens = make(map[string]TokenTotals)
+	}
+	seen, known := s.messageUsage[messageID]
+	if !known {
+		// A message's lines come together, so only the last few need remembering
+		s.messageOrder = append(s.messageOrder, messageID)
+		if len(s.messageOrder) > usageMessagesKept {
+			delete(s.messageUsage, s.messageOrder[0])
+			s.messageOrder = s.messageOrder[1:]
+		}
+	}
+
+	grow := func(cur, prev int) int64 {
+		if cur > prev {
+			return int64(cur - prev)
+		}
+		return 0
+	}
+	delta := TokenTotals{
+		Input:         grow(u.InputTokens, seen.InputTokens),
+		CacheCreation: grow(u.CacheCreationTokens, seen.CacheCreationTokens),
+		CacheRead:     grow(u.CacheReadTokens, seen.CacheReadTokens),
+		Output:        grow(u.OutputTokens, seen.OutputTokens),
+	}
+
+	s.messageUsage[messageID] = TokenUsage{
+		InputTokens:         max(u.InputTokens, seen.InputTokens),
+		CacheReadTokens:     max(u.CacheReadTokens, seen.CacheReadTokens),
+		CacheCreationTokens: max(u.CacheCreationTokens, seen.CacheCreationTokens),
+		OutputTokens:        max(u.OutputTokens, seen.OutputTokens),
+	}
+	s.Tokens.Add(delta)
+	if model != "" {
+		modelTotals := s.ModelTokens[model]
+		modelTotals.Add(delta)
+		s.ModelTokens[model] = modelTotals
+	}
+	return delta
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// RENDER EXPORT (cq render)
+// ============================================================================
+//
+// Replays a transcript through the real animation system, game state and
+// renderer at a fixed timestep on a software canvas, and writes the frames out
+// as an animated GIF or a PNG sequence. No window or GPU is needed. Renders
+// use a scratch copy of the career profile, so they never grant XP.
+
+const (
+	defaultRenderFPS   = 20
+	defaultRenderMax   = 30 * time.Second // Keeps GIFs (held in memory) a sane size
+	renderTail         = 2 * time.Second  // Keep rendering after the last event so effects finish
+	defaultRenderSpeed = 200 * time.Millisecond
+)
+
+// RenderOptions configures a render export
+type RenderOptions struct {
+	Input  string
+	Output string        // .gif for an animated GIF, anything else is a PNG directory
+	Scale  int           // Integer upscale factor
+	FPS    int           // Frames per second of the output
+	Speed  time.Duration // Time between replayed events, like cq replay -s
+	From   time.Duration // Start of the captured range on the replay timeline
+	To     time.Duration // End of the captured range (0 = end of replay)
+	Max    time.Duration // Longest clip to capture (0 = no limit)
+}
+
+// runRender parses the render command line and exports the clip
+func runRender(args []string) {
+	opts := RenderOptions{
+		Output: "out.gif",
+		Scale:  1,
+		FPS:    defaultRenderFPS,
+		Speed:  defaultRenderSpeed,
+		Max:    defaultRenderMax,
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		value := ""
+		if strings.HasPrefix(arg, "-") && i+1 < len(args) {
+			value = args[i+1]
+		}
+		var err error
+		switch arg {
+		case "-o", "--output":
+			if value == "" {
+				err = errors.New("missing output path")
+			}
+			opts.Output = value
+			i++
+		case "--scale":
+			opts.Scale, err = strconv.Atoi(value)
+			i++
+		case "--fps":
+			opts.FPS, err = strconv.Atoi(value)
+			i++
+		case "-s", "--speed":
+			var ms int
+			ms, err = strconv.Atoi(value)
+			opts.Speed = time.Duration(ms) * time.Millisecond
+			i++
+		case "--from":
+			opts.From, err = parseRenderDuration(value)
+			i++
+		case "--to":
+			opts.To, err = parseRenderDuration(value)
+			i++
+		case "--max":
+			opts.Max, err = parseRenderDuration(value)
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") {
+				err = fmt.Errorf("unknown option %s", arg)
+			} else {
+				opts.Input = arg
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", arg, err)
+			os.Exit(1)
+		}
+	}
+
+	if opts.Input == "" {
+		fmt.Fprintln(os.Stderr, "Error: render requires a conversation file")
+		fmt.Fprintln(os.Stderr, "Usage: cq render <file.jsonl> [-o out.gif] [--scale N] [--fps N] [--from 10s] [--to 40s] [--max 30s]")
+		os.Exit(1)
+	}
+	if opts.Scale < 1 || opts.FPS < 1 || opts.Speed <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: --scale, --fps and --speed must be positive")
+		os.Exit(1)
+	}
+
+	if err := renderTranscript(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseRenderDuration accepts Go durations ("1m30s") or plain seconds ("90")
+func parseRenderDuration(s string) (time.Duration, error) {
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// loadReplayEvents parses a whole transcript into the events a replay would emit
+func loadReplayEvents(path string) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %w", err)
+	}
+	defer file.Close()
+
+	watcher := NewWatcher()
+	events := []Event{{Type: EventSystemInit, Details: "Replaying: " + filepath.Base(path)}}
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 1024*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+	for scanner.Scan() {
+		events = append(events, watcher.parseLine(scanner.Text())...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	events = append(events, Event{Type: EventSuccess, Details: "Replay complete"})
+	return events, nil
+}
+
+// renderTranscript plays a transcript headlessly and writes the captured frames
+func renderTranscript(opts RenderOptions) error {
+	events, err := loadReplayEvents(opts.Input)
+	if err != nil {
+		return err
+	}
+
+	// Work out the captured range on the replay timeline
+	end := time.Duration(len(events))*opts.Speed + renderTail
+	to := opts.To
+	if to <= 0 || to > end {
+		to = end
+	}
+	if opts.Max > 0 && to-opts.From > opts.Max {
+		to = opts.From + opts.Max
+	}
+	if opts.From >= to {
+		return fmt.Errorf("nothing to render: --from %s is past the end of the replay (%s)", opts.From, end)
+	}
+
+	config := LoadConfig("config.json")
+	profile := LoadProfile()
+	profile.scratch = true
+
+	canvas := NewImageCanvas(screenWidth, screenHeight)
+	renderer := NewRendererOn(config, canvas)
+	defer renderer.Unload()
+	renderer.SetProfile(profile)
+	animations := NewAnimationSystem()
+	gameState := newGameState(config, profile)
+
+	// Light the scene for when the session happened, not when it's rendered
+	sessionTime := time.Now()
+	for _, event := range events {
+		if !event.Timestamp.IsZero() {
+			sessionTime = event.Timestamp.Local()
+			break
+		}
+	}
+	renderer.SetClock(func() time.Time { return sessionTime })
+
+	dt := time.Second / time.Duration(opts.FPS)
+	fixedFrameTime = float32(dt.Seconds())
+	fixedTime = 0
+	defer func() { fixedFrameTime = 0 }()
+
+	out, err := newFrameWriter(opts)
+	if err != nil {
+		return err
+	}
+
+	next := 0
+	for now := time.Duration(0); now < to; now += dt {
+		// Fire every event that's due by this frame
+		for next < len(events) && time.Duration(next)*opts.Speed <= now {
+			animations.HandleEvent(events[next])
+			gameState.HandleEvent(events[next])
+			renderer.HandleEvent(events[next])
+			if ts := events[next].Timestamp; !ts.IsZero() {
+				sessionTime = ts.Local()
+			}
+			next++
+		}
+
+		step := fixedFrameTime
+		animations.Update(step)
+		gameState.Update(step)
+		renderer.Update(step)
+		animations.SetActive(gameState.IsActive)
+		if gameState.PendingHurt {
+			gameState.PendingHurt = false
+			animations.HandleEvent(Event{Type: EventEnemyHit})
+		}
+		if gameState.IsActive {
+			renderer.UpdateScroll(step)
+		}
+		// No one to pick from a chest - take the first item
+		if gameState.ActiveChest != nil && gameState.ActiveChest.IsInteractive() {
+			gameState.ActiveChest.ConfirmSelection()
+		}
+
+		canvas.Clear(sceneBackground)
+		renderer.Draw(animations.GetState())
+		renderer.DrawGameUI(gameState)
+		renderer.DrawTreasureChest(gameState)
+
+		if now >= opts.From {
+			if err := out.Add(scaleFrame(canvas.Image, opts.Scale)); err != nil {
+				return err
+			}
+		}
+		stepRenderClock()
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Rendered %d frames (%s) to %s\n", out.frames, (to - opts.From).Round(time.Millisecond), opts.Output)
+	return nil
+}
+
+// scaleFrame returns an opaque copy of a frame, upscaled by an integer factor
+func scaleFrame(src *image.RGBA, scale int) *image.RGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	frame := image.NewRGBA(image.Rect(0, 0, w*scale, h*scale))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := src.RGBAAt(x, y)
+			c.A = 255
+			for sy := 0; sy < scale; sy++ {
+				for sx := 0; sx < scale; sx++ {
+					frame.SetRGBA(x*scale+sx, y*scale+sy, c)
+				}
+			}
+		}
+	}
+	return frame
+}
+
+// frameWriter writes captured frames as a GIF or a PNG sequence
+type frameWriter struct {
+	opts   RenderOptions
+	frames int
+
+	// GIF frames are held until Close, with repeats folded into the delay
+	anim     gif.GIF
+	last     *image.RGBA
+	delay    int // Centiseconds per frame
+	leftover float64
+}
+
+// newFrameWriter prepares the output for a render
+func newFrameWriter(opts RenderOptions) (*frameWriter, error) {
+	w := &frameWriter{opts: opts}
+	if w.isGIF() {
+		w.delay = 100 / opts.FPS
+		return w, nil
+	}
+	return w, os.MkdirAll(opts.Output, 0755)
+}
+
+// isGIF returns true when the output is an animated GIF
+func (w *frameWriter) isGIF() bool {
+	return strings.EqualFold(filepath.Ext(w.opts.Output), ".gif")
+}
+
+// Add writes or buffers one frame
+func (w *frameWriter) Add(frame *image.RGBA) error {
+	w.frames++
+	if !w.isGIF() {
+		path := filepath.Join(w.opts.Output, fmt.Sprintf("frame-%05d.png", w.frames))
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := png.Encode(file, frame); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+
+	// Spread the rounding of 100/fps over the frames so the clip keeps time
+	delay := w.delay
+	w.leftover += 100/float64(w.opts.FPS) - float64(w.delay)
+	if w.leftover >= 1 {
+		delay++
+		w.leftover--
+	}
+
+	if w.last != nil && bytes.Equal(w.last.Pix, frame.Pix) {
+		w.anim.Delay[len(w.anim.Delay)-1] += delay
+		return nil
+	}
+	w.last = frame
+	w.anim.Image = append(w.anim.Image, toPaletted(frame))
+	w.anim.Delay = append(w.anim.Delay, delay)
+	return nil
+}
+
+// Close finishes the output
+func (w *frameWriter) Close() error {
+	if !w.isGIF() {
+		return nil
+	}
+	file, err := os.Create(w.opts.Output)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(file, &w.anim); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// toPaletted converts a frame for GIF. Pixel art rarely needs more than 256
+// colors, so the exact colors are used when they fit.
+func toPaletted(frame *image.RGBA) *image.Paletted {
+	seen := make(map[color.RGBA]bool)
+	var pal color.Palette
+	for i := 0; i < len(frame.Pix); i += 4 {
+		c := color.RGBA{R: frame.Pix[i], G: frame.Pix[i+1], B: frame.Pix[i+2], A: frame.Pix[i+3]}
+		if !seen[c] {
+			seen[c] = true
+			pal = append(pal, c)
+			if len(pal) > 256 {
+				pal = palette.Plan9
+				break
+			}
+		}
+	}
+
+	out := image.NewPaletted(frame.Bounds(), pal)
+	draw.Draw(out, frame.Bounds(), frame, image.Point{}, draw.Src)
+	return out
+}
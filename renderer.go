@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -62,6 +64,34 @@ const (
 	enemyFrameHeight = 16
 )
 
+// Render clock. The window uses raylib's clock; cq render steps a fixed
+// amount per frame so exported clips come out the same on every machine.
+var (
+	fixedFrameTime float32 // Seconds per frame (0 = use raylib's clock)
+	fixedTime      float64 // Seconds since start, advanced by stepRenderClock
+)
+
+// renderFrameTime returns the seconds since the last frame
+func renderFrameTime() float32 {
+	if fixedFrameTime > 0 {
+		return fixedFrameTime
+	}
+	return rl.GetFrameTime()
+}
+
+// renderTime returns the seconds since start
+func renderTime() float64 {
+	if fixedFrameTime > 0 {
+		return fixedTime
+	}
+	return rl.GetTime()
+}
+
+// stepRenderClock advances the fixed clock by one frame
+func stepRenderClock() {
+	fixedTime += float64(fixedFrameTime)
+}
+
 // Particle represents a visual effect particle
 type Particle struct {
 	X, Y     float32
@@ -75,6 +105,8 @@ type Particle struct {
 // Renderer handles all drawing operations
 type Renderer struct {
 	config           *Config
+	canvas           Canvas     // Where the scene is drawn
+	rng              *rand.Rand // Randomness for particles and effects
 	background       rl.Texture2D
 	spriteSheet      rl.Texture2D
 	miniSpriteSheet  rl.Texture2D
@@ -114,10 +146,47 @@ type Renderer struct {
 
 	// Parallax scrolling
 	scrollOffset float32
+	walked       float64 // Pixels walked this session, never wrapped
+
+	// Commits and pushes planted by the path
+	milestones []milestone
+	privacy    *PrivacyFilter
 
 	// Biome system
-	currentBiome int
-	biomeTimer   float32
+	biomes        []*BiomePack
+	currentBiome  int
+	biomeTimer    float32
+	pinnedBiome   int      // Config background, -1 to follow the rules
+	fadeFromBiome int      // Biome fading out, -1 when not crossfading
+	fadeFromTimer float32  // Its animation clock
+	biomeFade     float32  // Seconds into the crossfade
+	biomeProject  string   // Working directory from the transcript
+	biomeActivity []string // Recent tool calls, oldest first
+	clock         func() time.Time
+	sky           skyLight // Time of day, worked out each frame
+	season        season
+
+	// Weather over the biome
+	weather       Weather
+	weatherClouds float32 // Cloud cover on screen, easing towards the weather
+	weatherRain   float32 // Rain on screen, easing towards the weather
+	weatherDrops  []Particle
+	rainDue       float32 // Drops owed from earlier frames
+	lightning     float32 // Flash brightness, fading out
+	lightningX    int32
+	lightningSeed uint64 // Shape of the current bolt
+
+	// Claude's feelings and the face parts that show them
+	mood Mood
+	face faceParts
+
+	// World map
+	journeyMap     bool
+	journeyMapAnim float32 // 0.0 = closed, 1.0 = fully open
+
+	// Dungeon map
+	dungeonMap     bool
+	dungeonMapAnim float32 // 0.0 = closed, 1.0 = fully open
 
 	// Picker visibility
 	pickerExpanded bool
@@ -163,24 +232,42 @@ func (r *Renderer) SetProfile(profile *CareerProfile) {
 	}
 }
 
+// Seed restarts the particle and effect randomness from a fixed seed, so the
+// same frames come out every time
+func (r *Renderer) Seed(seed int64) {
+	r.rng = rand.New(rand.NewSource(seed))
+}
+
 // CycleBiome changes the current biome by delta (use 1 for next, -1 for previous)
 func (r *Renderer) CycleBiome(delta int) {
-	r.currentBiome = (r.currentBiome + delta + 5) % 5
+	n := len(r.biomes)
+	r.currentBiome = ((r.currentBiome+delta)%n + n) % n
 	r.biomeTimer = 0 // Reset biome timer
+	r.fadeFromBiome = -1
 }
 
-// SetBiome sets the biome directly (0-4)
+// SetBiome sets the biome directly (an index into BiomeNames)
 func (r *Renderer) SetBiome(index int) {
-	if index >= 0 && index < 5 {
+	if index >= 0 && index < len(r.biomes) {
 		r.currentBiome = index
 		r.biomeTimer = 0
+		r.fadeFromBiome = -1
 	}
 }
 
 // NewRenderer creates a new renderer with loaded assets
 func NewRenderer(config *Config) *Renderer {
+	return NewRendererOn(config, raylibCanvas{})
+}
+
+// NewRendererOn creates a renderer that draws the scene on the given canvas
+func NewRendererOn(config *Config, canvas Canvas) *Renderer {
 	r := &Renderer{
 		config:         config,
+		canvas:         canvas,
+		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:          time.Now,
+		privacy:        NewPrivacyFilter(config.Privacy),
 		particles:      make([]Particle, 0, 100),
 		trailParticles: make([]Particle, 0, 50),
 		currentHat:     -1, // No hat by default
@@ -188,8 +275,8 @@ func NewRenderer(config *Config) *Renderer {
 		currentAura:    -1, // No aura by default
 		currentTrail:   -1, // No trail by default
 		// Initialize aura and trail names (particle-based, no textures)
-		auraNames:  []string{"aura_pixel", "aura_flame", "aura_frost", "aura_electric", "aura_shadow", "aura_heart", "aura_code", "aura_rainbow"},
-		trailNames: []string{"trail_sparkle", "trail_flame", "trail_frost", "trail_hearts", "trail_pixel", "trail_rainbow"},
+		auraNames:  []string{"aura_pixel", "aura_flame", "aura_frost", "aura_electric", "aura_shadow", "aura_heart", "aura_code", "aura_rainbow", "aura_snake"},
+		trailNames: []string{"trail_sparkle", "trail_flame", "trail_frost", "trail_hearts", "trail_pixel", "trail_rainbow", "trail_gopher"},
 		// Modal picker defaults
 		pickerPreviewHat:   -2, // -2 means "use current" (distinct from -1 = none selected)
 		pickerPreviewFace:  -2,
@@ -201,7 +288,7 @@ func NewRenderer(config *Config) *Renderer {
 	// Try to load sprite sheet
 	spritePath := getAssetPath("claude/spritesheet.png")
 	if _, err := os.Stat(spritePath); err == nil {
-		r.spriteSheet = rl.LoadTexture(spritePath)
+		r.spriteSheet = r.canvas.LoadTexture(spritePath)
 		r.hasSprites = true
 		fmt.Println("Loaded sprite sheet from:", spritePath)
 	} else {
@@ -211,7 +298,7 @@ func NewRenderer(config *Config) *Renderer {
 	// Try to load mini sprite sheet
 	miniSpritePath := getAssetPath("claude/mini_spritesheet.png")
 	if _, err := os.Stat(miniSpritePath); err == nil {
-		r.miniSpriteSheet = rl.LoadTexture(miniSpritePath)
+		r.miniSpriteSheet = r.canvas.LoadTexture(miniSpritePath)
 		r.hasMiniSprites = true
 		fmt.Println("Loaded mini sprite sheet from:", miniSpritePath)
 	}
@@ -219,7 +306,7 @@ func NewRenderer(config *Config) *Renderer {
 	// Try to load enemy sprite sheet
 	enemySpritePath := getAssetPath("enemies/enemy_spritesheet.png")
 	if _, err := os.Stat(enemySpritePath); err == nil {
-		r.enemySpriteSheet = rl.LoadTexture(enemySpritePath)
+		r.enemySpriteSheet = r.canvas.LoadTexture(enemySpritePath)
 		r.hasEnemySprites = true
 		fmt.Println("Loaded enemy sprite sheet from:", enemySpritePath)
 	}
@@ -227,11 +314,15 @@ func NewRenderer(config *Config) *Renderer {
 	// Try to load chest sprite
 	chestPath := getAssetPath("ui/chest.png")
 	if _, err := os.Stat(chestPath); err == nil {
-		r.chestTexture = rl.LoadTexture(chestPath)
+		r.chestTexture = r.canvas.LoadTexture(chestPath)
 		r.hasChestTexture = true
 		fmt.Println("Loaded chest sprite from:", chestPath)
 	}
 
+	// Load biome packs
+	r.loadBiomes()
+	r.applyBiomeConfig()
+
 	// Load all accessories
 	r.loadHats()
 	r.loadFaces()
@@ -248,11 +339,13 @@ func NewRenderer(config *Config) *Renderer {
 func (r *Renderer) Draw(state *AnimationState) {
 	// Draw background
 	r.drawBackground()
+	r.drawMilestones()
 
 	// Update and draw trail particles (behind Claude)
 	r.updateTrailParticles()
 	r.drawTrailParticles()
 	r.spawnTrailParticles(state)
+	r.drawCompanion(state)
 
 	// Update and draw particles
 	r.updateParticles()
@@ -263,6 +356,7 @@ func (r *Renderer) Draw(state *AnimationState) {
 
 	// Draw Claude sprite
 	r.drawClaude(state)
+	r.drawEmotion(state)
 
 	// Draw face accessory (under hat)
 	r.drawFace(state)
@@ -279,6 +373,21 @@ func (r *Renderer) Draw(state *AnimationState) {
 	}
 }
 
+// HandleEvent lets the scenery and Claude's face react to an event
+func (r *Renderer) HandleEvent(event Event) {
+	r.trackBiomeActivity(event)
+	r.weather.HandleEvent(event)
+	r.mood.HandleEvent(event)
+	r.plantMilestone(event)
+}
+
+// Update advances what the renderer keeps track of over time. It runs every
+// frame, busy or idle, unlike the scroll.
+func (r *Renderer) Update(dt float32) {
+	r.weather.Update(dt)
+	r.mood.Update(dt)
+}
+
 
 func (r *Renderer) drawBackground() {
 	// Always use parallax background (Quest mode)
@@ -287,43 +396,57 @@ func (r *Renderer) drawBackground() {
 
 // drawParallaxBackground renders the infinite scrolling landscape with biomes
 func (r *Renderer) drawParallaxBackground() {
-	switch r.currentBiome {
-	case 0:
-		r.drawBiomeEnchantedForest()
-	case 1:
-		r.drawBiomeMountainJourney()
-	case 2:
-		r.drawBiomeMidnightQuest()
-	case 3:
-		r.drawBiomeKingdomRoad()
-	case 4:
-		r.drawBiomeWizardLibrary()
+	now := r.clock()
+	r.sky, r.season = lightAt(now), seasonAt(now)
+
+	if r.fadeFromBiome >= 0 {
+		r.drawBiomeCrossfade()
+	} else {
+		r.drawBiome(r.biomes[r.currentBiome])
+	}
+
+	r.drawSkyShade()
+	if !r.biomes[r.currentBiome].Indoor {
+		r.drawNightFireflies()
+		r.drawSeason()
+		r.drawWeather()
 	}
 }
 
 func (r *Renderer) drawDebug(state *AnimationState) {
 	// Animation name
-	rl.DrawText(state.CurrentAnim.String(), 5, 5, 8, rl.Green)
+	r.canvas.DrawText(state.CurrentAnim.String(), 5, 5, 8, rl.Green)
 
 	// Frame counter
 	frameText := fmt.Sprintf("Frame: %d", state.Frame)
-	rl.DrawText(frameText, 5, 15, 8, rl.Green)
+	r.canvas.DrawText(frameText, 5, 15, 8, rl.Green)
 
 	// Particle count
 	particleText := fmt.Sprintf("Particles: %d", len(r.particles))
-	rl.DrawText(particleText, 5, 25, 8, rl.Green)
+	r.canvas.DrawText(particleText, 5, 25, 8, rl.Green)
 
 	// FPS
-	fpsText := fmt.Sprintf("FPS: %d", rl.GetFPS())
-	rl.DrawText(fpsText, 5, 35, 8, rl.Green)
+	fps := 0
+	if dt := renderFrameTime(); dt > 0 {
+		fps = int(1/dt + 0.5)
+	}
+	fpsText := fmt.Sprintf("FPS: %d", fps)
+	r.canvas.DrawText(fpsText, 5, 35, 8, rl.Green)
 }
 
 // Unload frees all loaded textures
 func (r *Renderer) Unload() {
 	if r.background.ID != 0 {
-		rl.UnloadTexture(r.background)
+		r.canvas.UnloadTexture(r.background)
 	}
 	if r.spriteSheet.ID != 0 {
-		rl.UnloadTexture(r.spriteSheet)
+		r.canvas.UnloadTexture(r.spriteSheet)
+	}
+	for _, pack := range r.biomes {
+		for _, layer := range pack.Layers {
+			if layer.texture.ID != 0 {
+				r.canvas.UnloadTexture(layer.texture)
+			}
+		}
 	}
 }
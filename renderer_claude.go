@@ -30,7 +30,7 @@ func (r *Renderer) drawClaude(state *AnimationState) {
 			Height: scaledH,
 		}
 
-		rl.DrawTexturePro(r.spriteSheet, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
+		r.canvas.DrawTexturePro(r.spriteSheet, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
 	} else {
 		// Fallback placeholder
 		r.drawPlaceholderClaude(int(x), int(y), state)
@@ -277,7 +277,7 @@ func (r *Renderer) drawHat(state *AnimationState) {
 		Height: hatH,
 	}
 
-	rl.DrawTexturePro(hat, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
+	r.canvas.DrawTexturePro(hat, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
 }
 
 func (r *Renderer) drawFace(state *AnimationState) {
@@ -375,7 +375,7 @@ func (r *Renderer) drawFace(state *AnimationState) {
 		Height: faceH,
 	}
 
-	rl.DrawTexturePro(face, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
+	r.canvas.DrawTexturePro(face, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
 }
 
 func (r *Renderer) drawPlaceholderClaude(x, y int, state *AnimationState) {
@@ -388,10 +388,10 @@ func (r *Renderer) drawPlaceholderClaude(x, y int, state *AnimationState) {
 	}
 
 	// Body
-	rl.DrawRectangle(int32(x+8), int32(y+20), 16, 24, color)
+	r.canvas.DrawRectangle(int32(x+8), int32(y+20), 16, 24, color)
 	// Head
-	rl.DrawCircle(int32(x+16), int32(y+14+bobOffset), 10, color)
+	r.canvas.DrawCircle(int32(x+16), int32(y+14+bobOffset), 10, color)
 	// Eyes
-	rl.DrawCircle(int32(x+13), int32(y+12+bobOffset), 2, rl.White)
-	rl.DrawCircle(int32(x+19), int32(y+12+bobOffset), 2, rl.White)
+	r.canvas.DrawCircle(int32(x+13), int32(y+12+bobOffset), 2, rl.White)
+	r.canvas.DrawCircle(int32(x+19), int32(y+12+bobOffset), 2, rl.White)
 }
@@ -2,7 +2,6 @@ package main
 
 import (
 	"math"
-	"math/rand"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -26,7 +25,7 @@ func (r *Renderer) drawAura(state *AnimationState) {
 	}
 
 	auraName := r.auraNames[auraIdx]
-	time := float32(rl.GetTime())
+	time := float32(renderTime())
 	cx := float32(claudeX + spriteFrameWidth)  // Center X
 	cy := float32(claudeY + spriteFrameHeight) // Center Y
 
@@ -47,6 +46,8 @@ func (r *Renderer) drawAura(state *AnimationState) {
 		r.drawAuraCode(cx, cy, time)
 	case "aura_rainbow":
 		r.drawAuraRainbow(cx, cy, time)
+	case "aura_snake":
+		r.drawAuraSnake(cx, cy, time)
 	}
 }
 
@@ -60,8 +61,8 @@ func (r *Renderer) drawAuraPixelDust(cx, cy, time float32) {
 		y := cy + float32(math.Sin(angle)*radius) - 20
 		alpha := uint8(200 + 55*math.Sin(float64(time*6.0)+float64(i)))
 		// Bright sparkle with glow
-		rl.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 255, B: 200, A: alpha / 2})
-		rl.DrawCircle(int32(x), int32(y), 1, rl.Color{R: 255, G: 255, B: 255, A: alpha})
+		r.canvas.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 255, B: 200, A: alpha / 2})
+		r.canvas.DrawCircle(int32(x), int32(y), 1, rl.Color{R: 255, G: 255, B: 255, A: alpha})
 	}
 	// Inner sparkle bursts
 	for i := 0; i < 8; i++ {
@@ -69,7 +70,7 @@ func (r *Renderer) drawAuraPixelDust(cx, cy, time float32) {
 		radius := 15.0 + 5.0*math.Sin(float64(time*5.0)+float64(i))
 		x := cx + float32(math.Cos(angle)*radius)
 		y := cy + float32(math.Sin(angle)*radius) - 20
-		rl.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 255, B: 100, A: 255})
+		r.canvas.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 255, B: 100, A: 255})
 	}
 }
 
@@ -79,8 +80,8 @@ func (r *Renderer) drawAuraFlame(cx, cy, time float32) {
 
 	// Layer 1: Outer glow - warm ambient light
 	glowPulse := float32(0.7 + 0.3*math.Sin(float64(time*4.0)))
-	rl.DrawCircle(int32(cx), int32(cy-10), 35, rl.Color{R: 255, G: 100, B: 20, A: uint8(30 * glowPulse)})
-	rl.DrawCircle(int32(cx), int32(cy-10), 25, rl.Color{R: 255, G: 150, B: 50, A: uint8(50 * glowPulse)})
+	r.canvas.DrawCircle(int32(cx), int32(cy-10), 35, rl.Color{R: 255, G: 100, B: 20, A: uint8(30 * glowPulse)})
+	r.canvas.DrawCircle(int32(cx), int32(cy-10), 25, rl.Color{R: 255, G: 150, B: 50, A: uint8(50 * glowPulse)})
 
 	// Layer 2: Back flames (darker, behind Claude)
 	for i := 0; i < 7; i++ {
@@ -110,7 +111,7 @@ func (r *Renderer) drawAuraFlame(cx, cy, time float32) {
 			red := uint8(200 - progress*80)
 			green := uint8(80 - progress*60)
 			alpha := uint8((1.0 - progress*0.8) * 150)
-			rl.DrawCircle(int32(x), int32(y), width, rl.Color{R: red, G: green, B: 10, A: alpha})
+			r.canvas.DrawCircle(int32(x), int32(y), width, rl.Color{R: red, G: green, B: 10, A: alpha})
 		}
 	}
 
@@ -167,11 +168,11 @@ func (r *Renderer) drawAuraFlame(cx, cy, time float32) {
 			}
 
 			alpha := uint8((1.0 - progress*0.7) * 255)
-			rl.DrawCircle(int32(x), int32(y), width, rl.Color{R: red, G: green, B: blue, A: alpha})
+			r.canvas.DrawCircle(int32(x), int32(y), width, rl.Color{R: red, G: green, B: blue, A: alpha})
 
 			// Inner bright core for lower parts
 			if progress < 0.3 && width > 1.5 {
-				rl.DrawCircle(int32(x), int32(y), width*0.5, rl.Color{R: 255, G: 255, B: 255, A: alpha / 2})
+				r.canvas.DrawCircle(int32(x), int32(y), width*0.5, rl.Color{R: 255, G: 255, B: 255, A: alpha / 2})
 			}
 		}
 	}
@@ -196,16 +197,16 @@ func (r *Renderer) drawAuraFlame(cx, cy, time float32) {
 		alpha := uint8((1.0 - progress) * 255)
 
 		// Color fades from yellow to orange to red
-		var r, g uint8
+		var red, green uint8
 		if progress < 0.3 {
-			r, g = 255, 220
+			red, green = 255, 220
 		} else if progress < 0.6 {
-			r, g = 255, uint8(220-progress*200)
+			red, green = 255, uint8(220-progress*200)
 		} else {
-			r, g = uint8(255-progress*100), 50
+			red, green = uint8(255-progress*100), 50
 		}
 
-		rl.DrawCircle(int32(sparkX), int32(sparkY), size, rl.Color{R: r, G: g, B: 30, A: alpha})
+		r.canvas.DrawCircle(int32(sparkX), int32(sparkY), size, rl.Color{R: red, G: green, B: 30, A: alpha})
 	}
 
 	// Layer 5: Heat shimmer particles (subtle)
@@ -215,7 +216,7 @@ func (r *Renderer) drawAuraFlame(cx, cy, time float32) {
 		x := cx + float32(math.Sin(float64(time*2.0)+seed)*20)
 		y := baseY - 50 - float32(phase*25)
 		alpha := uint8(40 + 30*math.Sin(float64(time*5.0)+seed))
-		rl.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 200, B: 150, A: alpha})
+		r.canvas.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 200, B: 150, A: alpha})
 	}
 }
 
@@ -233,11 +234,11 @@ func (r *Renderer) drawAuraFrost(cx, cy, time float32) {
 			alpha = 50
 		}
 		// Snowflake with cross pattern
-		rl.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 180, G: 220, B: 255, A: alpha})
-		rl.DrawPixel(int32(x-2), int32(y), rl.Color{R: 220, G: 240, B: 255, A: alpha})
-		rl.DrawPixel(int32(x+2), int32(y), rl.Color{R: 220, G: 240, B: 255, A: alpha})
-		rl.DrawPixel(int32(x), int32(y-2), rl.Color{R: 220, G: 240, B: 255, A: alpha})
-		rl.DrawPixel(int32(x), int32(y+2), rl.Color{R: 220, G: 240, B: 255, A: alpha})
+		r.canvas.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 180, G: 220, B: 255, A: alpha})
+		r.canvas.DrawPixel(int32(x-2), int32(y), rl.Color{R: 220, G: 240, B: 255, A: alpha})
+		r.canvas.DrawPixel(int32(x+2), int32(y), rl.Color{R: 220, G: 240, B: 255, A: alpha})
+		r.canvas.DrawPixel(int32(x), int32(y-2), rl.Color{R: 220, G: 240, B: 255, A: alpha})
+		r.canvas.DrawPixel(int32(x), int32(y+2), rl.Color{R: 220, G: 240, B: 255, A: alpha})
 	}
 	// Icy glow ring
 	for i := 0; i < 12; i++ {
@@ -245,7 +246,7 @@ func (r *Renderer) drawAuraFrost(cx, cy, time float32) {
 		radius := 25.0 + 5.0*math.Sin(float64(time*2.0)+float64(i))
 		x := cx + float32(math.Cos(angle)*radius)
 		y := cy - 20 + float32(math.Sin(angle)*radius*0.6)
-		rl.DrawCircle(int32(x), int32(y), 3, rl.Color{R: 150, G: 200, B: 255, A: 150})
+		r.canvas.DrawCircle(int32(x), int32(y), 3, rl.Color{R: 150, G: 200, B: 255, A: 150})
 	}
 }
 
@@ -253,8 +254,8 @@ func (r *Renderer) drawAuraFrost(cx, cy, time float32) {
 func (r *Renderer) drawAuraElectric(cx, cy, time float32) {
 	// Bright yellow glow around Claude - larger
 	glowAlpha := uint8(60 + 40*math.Sin(float64(time*4.0)))
-	rl.DrawCircle(int32(cx), int32(cy-20), 38, rl.Color{R: 255, G: 240, B: 100, A: glowAlpha / 5})
-	rl.DrawCircle(int32(cx), int32(cy-20), 28, rl.Color{R: 255, G: 255, B: 150, A: glowAlpha / 3})
+	r.canvas.DrawCircle(int32(cx), int32(cy-20), 38, rl.Color{R: 255, G: 240, B: 100, A: glowAlpha / 5})
+	r.canvas.DrawCircle(int32(cx), int32(cy-20), 28, rl.Color{R: 255, G: 255, B: 150, A: glowAlpha / 3})
 
 	// Lightning bolts - jagged lines radiating outward
 	for i := 0; i < 10; i++ {
@@ -288,10 +289,10 @@ func (r *Renderer) drawAuraElectric(cx, cy, time float32) {
 
 			// Draw bolt segment - bright yellow core with white highlight
 			alpha := uint8(float64(255) * (1.0 - progress*0.3) * boltPhase)
-			rl.DrawLine(int32(prevX), int32(prevY), int32(nextX), int32(nextY), rl.Color{R: 255, G: 255, B: 200, A: alpha})
+			r.canvas.DrawLine(int32(prevX), int32(prevY), int32(nextX), int32(nextY), rl.Color{R: 255, G: 255, B: 200, A: alpha})
 			// Glow around bolt
-			rl.DrawLine(int32(prevX-1), int32(prevY), int32(nextX-1), int32(nextY), rl.Color{R: 255, G: 240, B: 80, A: alpha / 2})
-			rl.DrawLine(int32(prevX+1), int32(prevY), int32(nextX+1), int32(nextY), rl.Color{R: 255, G: 240, B: 80, A: alpha / 2})
+			r.canvas.DrawLine(int32(prevX-1), int32(prevY), int32(nextX-1), int32(nextY), rl.Color{R: 255, G: 240, B: 80, A: alpha / 2})
+			r.canvas.DrawLine(int32(prevX+1), int32(prevY), int32(nextX+1), int32(nextY), rl.Color{R: 255, G: 240, B: 80, A: alpha / 2})
 
 			prevX, prevY = nextX, nextY
 		}
@@ -305,9 +306,9 @@ func (r *Renderer) drawAuraElectric(cx, cy, time float32) {
 		y := cy - 20 + float32(math.Sin(angle)*radius*0.7)
 		alpha := uint8(180 + 75*math.Sin(float64(time*5.0)+float64(i)))
 		// Yellow-white sparks
-		rl.DrawCircle(int32(x), int32(y), 1, rl.Color{R: 255, G: 255, B: 200, A: alpha})
+		r.canvas.DrawCircle(int32(x), int32(y), 1, rl.Color{R: 255, G: 255, B: 200, A: alpha})
 		if i%3 == 0 {
-			rl.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 240, B: 80, A: alpha / 2})
+			r.canvas.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 255, G: 240, B: 80, A: alpha / 2})
 		}
 	}
 }
@@ -322,9 +323,9 @@ func (r *Renderer) drawAuraShadow(cx, cy, time float32) {
 		y := cy - 15 + float32(math.Sin(angle)*radius*0.5)
 		alpha := uint8(180 + 70*math.Sin(float64(time*2.0)+float64(i)))
 		// Layered dark wisps
-		rl.DrawCircle(int32(x), int32(y), 5, rl.Color{R: 40, G: 20, B: 60, A: alpha / 2})
-		rl.DrawCircle(int32(x), int32(y), 3, rl.Color{R: 60, G: 30, B: 90, A: alpha})
-		rl.DrawCircle(int32(x), int32(y), 1, rl.Color{R: 100, G: 50, B: 140, A: alpha})
+		r.canvas.DrawCircle(int32(x), int32(y), 5, rl.Color{R: 40, G: 20, B: 60, A: alpha / 2})
+		r.canvas.DrawCircle(int32(x), int32(y), 3, rl.Color{R: 60, G: 30, B: 90, A: alpha})
+		r.canvas.DrawCircle(int32(x), int32(y), 1, rl.Color{R: 100, G: 50, B: 140, A: alpha})
 	}
 	// Inner void particles
 	for i := 0; i < 8; i++ {
@@ -332,7 +333,7 @@ func (r *Renderer) drawAuraShadow(cx, cy, time float32) {
 		radius := 12.0 + 4.0*math.Sin(float64(time*3.0)+float64(i))
 		x := cx + float32(math.Cos(angle)*radius)
 		y := cy - 20 + float32(math.Sin(angle)*radius*0.6)
-		rl.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 20, G: 10, B: 30, A: 200})
+		r.canvas.DrawCircle(int32(x), int32(y), 2, rl.Color{R: 20, G: 10, B: 30, A: 200})
 	}
 }
 
@@ -352,14 +353,14 @@ func (r *Renderer) drawAuraHeart(cx, cy, time float32) {
 		c := rl.Color{R: 255, G: 80, B: 130, A: alpha}
 		cLight := rl.Color{R: 255, G: 150, B: 180, A: alpha}
 		// Heart made of circles
-		rl.DrawCircle(int32(x-2), int32(y), 2, c)
-		rl.DrawCircle(int32(x+2), int32(y), 2, c)
-		rl.DrawCircle(int32(x), int32(y+2), 2, c)
-		rl.DrawPixel(int32(x-3), int32(y+1), c)
-		rl.DrawPixel(int32(x+3), int32(y+1), c)
-		rl.DrawPixel(int32(x), int32(y+4), c)
+		r.canvas.DrawCircle(int32(x-2), int32(y), 2, c)
+		r.canvas.DrawCircle(int32(x+2), int32(y), 2, c)
+		r.canvas.DrawCircle(int32(x), int32(y+2), 2, c)
+		r.canvas.DrawPixel(int32(x-3), int32(y+1), c)
+		r.canvas.DrawPixel(int32(x+3), int32(y+1), c)
+		r.canvas.DrawPixel(int32(x), int32(y+4), c)
 		// Highlight
-		rl.DrawPixel(int32(x-2), int32(y-1), cLight)
+		r.canvas.DrawPixel(int32(x-2), int32(y-1), cLight)
 	}
 }
 
@@ -379,11 +380,11 @@ func (r *Renderer) drawAuraCode(cx, cy, time float32) {
 		charIdx := (i + int(time*8)) % len(codeChars)
 		char := string(codeChars[charIdx])
 		// Bright green with glow
-		rl.DrawText(char, int32(baseX), int32(y), 10, rl.Color{R: 0, G: 255, B: 80, A: alpha})
+		r.canvas.DrawText(char, int32(baseX), int32(y), 10, rl.Color{R: 0, G: 255, B: 80, A: alpha})
 		// Trail
 		if yOffset > 10 {
 			charIdx2 := (i + int(time*8) + 3) % len(codeChars)
-			rl.DrawText(string(codeChars[charIdx2]), int32(baseX), int32(y-10), 10, rl.Color{R: 0, G: 200, B: 60, A: alpha / 2})
+			r.canvas.DrawText(string(codeChars[charIdx2]), int32(baseX), int32(y-10), 10, rl.Color{R: 0, G: 200, B: 60, A: alpha / 2})
 		}
 	}
 }
@@ -399,9 +400,9 @@ func (r *Renderer) drawAuraRainbow(cx, cy, time float32) {
 		hue := int(math.Mod(float64(i)*11.25+float64(time*200), 360))
 		color := hsvToRGB(hue, 1.0, 1.0)
 		color.A = 255
-		rl.DrawCircle(int32(x), int32(y), 3, color)
+		r.canvas.DrawCircle(int32(x), int32(y), 3, color)
 		color.A = 150
-		rl.DrawCircle(int32(x), int32(y), 5, color)
+		r.canvas.DrawCircle(int32(x), int32(y), 5, color)
 	}
 	// Inner glow
 	for i := 0; i < 12; i++ {
@@ -412,7 +413,41 @@ func (r *Renderer) drawAuraRainbow(cx, cy, time float32) {
 		hue := int(math.Mod(float64(i)*30+float64(time*300), 360))
 		color := hsvToRGB(hue, 1.0, 1.0)
 		color.A = 200
-		rl.DrawCircle(int32(x), int32(y), 2, color)
+		r.canvas.DrawCircle(int32(x), int32(y), 2, color)
+	}
+}
+
+// drawAuraSnake - A striped blue and yellow snake coiling around Claude
+func (r *Renderer) drawAuraSnake(cx, cy, time float32) {
+	blue := rl.Color{R: 55, G: 118, B: 171, A: 255}
+	yellow := rl.Color{R: 255, G: 212, B: 59, A: 255}
+	head := float64(time * 1.8)
+	var hx, hy float32
+	// Tail first so the head ends up on top
+	for i := 34; i >= 0; i-- {
+		angle := head - float64(i)*0.14
+		x := cx + float32(math.Cos(angle)*30)
+		y := cy - 18 + float32(math.Sin(angle)*9) + float32(math.Sin(angle*3+float64(time*4))*2)
+		size := float32(3.2 - float32(i)*0.06)
+		c := blue
+		if (i/3)%2 == 1 {
+			c = yellow
+		}
+		r.canvas.DrawCircle(int32(x), int32(y), size, c)
+		hx, hy = x, y
+	}
+	// Head, eye and a flicking tongue
+	r.canvas.DrawCircle(int32(hx), int32(hy), 4, blue)
+	dir := float32(1)
+	if math.Sin(head) > 0 {
+		dir = -1 // Heading back the other way in front of Claude
+	}
+	r.canvas.DrawPixel(int32(hx+dir), int32(hy-2), yellow)
+	if math.Sin(float64(time*8)) > 0.3 {
+		tongue := rl.Color{R: 230, G: 50, B: 60, A: 255}
+		r.canvas.DrawLine(int32(hx+dir*4), int32(hy), int32(hx+dir*7), int32(hy), tongue)
+		r.canvas.DrawPixel(int32(hx+dir*8), int32(hy-1), tongue)
+		r.canvas.DrawPixel(int32(hx+dir*8), int32(hy+1), tongue)
 	}
 }
 
@@ -434,7 +469,7 @@ func (r *Renderer) spawnTrailParticles(state *AnimationState) {
 	}
 
 	// Spawn every few frames
-	if rand.Float32() > 0.3 {
+	if r.rng.Float32() > 0.3 {
 		return
 	}
 
@@ -442,8 +477,8 @@ func (r *Renderer) spawnTrailParticles(state *AnimationState) {
 	// Spawn position at Claude's feet (Claude walks right, dust kicks back left)
 	// Actual Claude: y = 160 - 64 + 10 = 106, feet at y = 170 (floor line)
 	// Spawn just above floor behind Claude's center
-	spawnX := float32(claudeX + 15 + rand.Intn(10)) // 143-153, behind Claude's center
-	spawnY := float32(166 + rand.Intn(4))           // 166-170, at/just above foot level
+	spawnX := float32(claudeX + 15 + r.rng.Intn(10)) // 143-153, behind Claude's center
+	spawnY := float32(166 + r.rng.Intn(4))           // 166-170, at/just above foot level
 
 	switch trailName {
 	case "trail_sparkle":
@@ -465,9 +500,9 @@ func (r *Renderer) spawnTrailSparkle(x, y float32) {
 	// Golden dust kicked back left from feet
 	p := Particle{
 		X:       x,
-		Y:       y + float32(rand.Intn(4)-2),
-		VX:      float32(-rand.Float32()*3 - 1),    // Move LEFT (negative)
-		VY:      float32(-rand.Float32()*1.5 - 0.5), // Slight arc up
+		Y:       y + float32(r.rng.Intn(4)-2),
+		VX:      float32(-r.rng.Float32()*3 - 1),    // Move LEFT (negative)
+		VY:      float32(-r.rng.Float32()*1.5 - 0.5), // Slight arc up
 		Life:    0.9,
 		MaxLife: 0.9,
 		Color:   rl.Color{R: 255, G: 255, B: 200, A: 255},
@@ -480,12 +515,12 @@ func (r *Renderer) spawnTrailFlame(x, y float32) {
 	// Fire trail kicked back
 	p := Particle{
 		X:       x,
-		Y:       y + float32(rand.Intn(3)),
-		VX:      float32(-rand.Float32()*2.5 - 1), // Move LEFT
-		VY:      float32(-rand.Float32()*2 - 1),   // Rise up (fire goes up)
+		Y:       y + float32(r.rng.Intn(3)),
+		VX:      float32(-r.rng.Float32()*2.5 - 1), // Move LEFT
+		VY:      float32(-r.rng.Float32()*2 - 1),   // Rise up (fire goes up)
 		Life:    0.7,
 		MaxLife: 0.7,
-		Color:   rl.Color{R: 255, G: uint8(150 + rand.Intn(100)), B: 50, A: 255},
+		Color:   rl.Color{R: 255, G: uint8(150 + r.rng.Intn(100)), B: 50, A: 255},
 		Size:    3,
 	}
 	r.trailParticles = append(r.trailParticles, p)
@@ -495,9 +530,9 @@ func (r *Renderer) spawnTrailFrost(x, y float32) {
 	// Ice crystals scattered back
 	p := Particle{
 		X:       x,
-		Y:       y + float32(rand.Intn(4)-2),
-		VX:      float32(-rand.Float32()*3 - 0.5), // Move LEFT
-		VY:      float32(-rand.Float32()*1 + 0.3), // Slight float then settle
+		Y:       y + float32(r.rng.Intn(4)-2),
+		VX:      float32(-r.rng.Float32()*3 - 0.5), // Move LEFT
+		VY:      float32(-r.rng.Float32()*1 + 0.3), // Slight float then settle
 		Life:    1.0,
 		MaxLife: 1.0,
 		Color:   rl.Color{R: 150, G: 200, B: 255, A: 220},
@@ -507,15 +542,15 @@ func (r *Renderer) spawnTrailFrost(x, y float32) {
 }
 
 func (r *Renderer) spawnTrailHearts(x, y float32) {
-	if rand.Float32() > 0.5 {
+	if r.rng.Float32() > 0.5 {
 		return // Less frequent
 	}
 	// Hearts float up and back
 	p := Particle{
 		X:       x,
 		Y:       y,
-		VX:      float32(-rand.Float32()*2 - 0.5), // Drift LEFT
-		VY:      float32(-rand.Float32()*2 - 1),   // Float UP
+		VX:      float32(-r.rng.Float32()*2 - 0.5), // Drift LEFT
+		VY:      float32(-r.rng.Float32()*2 - 1),   // Float UP
 		Life:    1.2,
 		MaxLife: 1.2,
 		Color:   rl.Color{R: 255, G: 100, B: 150, A: 255},
@@ -534,26 +569,26 @@ func (r *Renderer) spawnTrailPixel(x, y float32) {
 	// Pixel dust kicked back
 	p := Particle{
 		X:       x,
-		Y:       y + float32(rand.Intn(3)),
-		VX:      float32(-rand.Float32()*3 - 1),   // Move LEFT
-		VY:      float32(-rand.Float32()*1.5 - 0.3), // Slight arc
+		Y:       y + float32(r.rng.Intn(3)),
+		VX:      float32(-r.rng.Float32()*3 - 1),   // Move LEFT
+		VY:      float32(-r.rng.Float32()*1.5 - 0.3), // Slight arc
 		Life:    0.8,
 		MaxLife: 0.8,
-		Color:   colors[rand.Intn(len(colors))],
+		Color:   colors[r.rng.Intn(len(colors))],
 		Size:    2,
 	}
 	r.trailParticles = append(r.trailParticles, p)
 }
 
 func (r *Renderer) spawnTrailRainbow(x, y float32) {
-	hue := int(math.Mod(float64(rl.GetTime()*180), 360))
+	hue := int(math.Mod(float64(renderTime()*180), 360))
 	color := hsvToRGB(hue, 1.0, 1.0)
 	// Rainbow dust kicked back
 	p := Particle{
 		X:       x,
-		Y:       y + float32(rand.Intn(3)),
-		VX:      float32(-rand.Float32()*3 - 1),  // Move LEFT
-		VY:      float32(-rand.Float32()*1.5 - 0.5), // Arc up
+		Y:       y + float32(r.rng.Intn(3)),
+		VX:      float32(-r.rng.Float32()*3 - 1),  // Move LEFT
+		VY:      float32(-r.rng.Float32()*1.5 - 0.5), // Arc up
 		Life:    1.0,
 		MaxLife: 1.0,
 		Color:   color,
@@ -562,9 +597,46 @@ func (r *Renderer) spawnTrailRainbow(x, y float32) {
 	r.trailParticles = append(r.trailParticles, p)
 }
 
+// drawCompanion draws a trail that walks along behind Claude instead of
+// kicking up particles
+func (r *Renderer) drawCompanion(state *AnimationState) {
+	trailIdx := r.GetPreviewTrail()
+	if trailIdx < 0 || trailIdx >= len(r.trailNames) || r.trailNames[trailIdx] != "trail_gopher" {
+		return
+	}
+
+	// A gopher trotting at Claude's heels, hopping while Claude walks
+	x, y := int32(claudeX+4), int32(170)
+	if state.CurrentAnim == AnimWalk {
+		y -= int32(math.Abs(math.Sin(renderTime()*10)) * 3)
+	}
+	fur := rl.Color{R: 106, G: 215, B: 229, A: 255}
+	shade := rl.Color{R: 70, G: 170, B: 190, A: 255}
+	tan := rl.Color{R: 235, G: 205, B: 165, A: 255}
+	white := rl.Color{R: 255, G: 255, B: 255, A: 255}
+	black := rl.Color{R: 20, G: 20, B: 30, A: 255}
+
+	r.canvas.DrawRectangle(x-5, y-1, 3, 1, tan) // Feet
+	r.canvas.DrawRectangle(x+2, y-1, 3, 1, tan)
+	r.canvas.DrawRectangle(x-6, y-4, 1, 2, tan) // Tail
+	r.canvas.DrawRectangle(x-5, y-13, 10, 12, fur)
+	r.canvas.DrawRectangle(x-4, y-14, 8, 1, fur)
+	r.canvas.DrawRectangle(x-5, y-3, 10, 2, shade)
+	r.canvas.DrawPixel(x-5, y-15, fur) // Ears
+	r.canvas.DrawPixel(x+4, y-15, fur)
+	r.canvas.DrawRectangle(x-3, y-12, 3, 3, white) // Big round eyes, looking ahead
+	r.canvas.DrawRectangle(x+1, y-12, 3, 3, white)
+	r.canvas.DrawPixel(x-1, y-11, black)
+	r.canvas.DrawPixel(x+3, y-11, black)
+	r.canvas.DrawRectangle(x, y-9, 3, 2, tan) // Muzzle, nose and teeth
+	r.canvas.DrawPixel(x+1, y-9, black)
+	r.canvas.DrawPixel(x+1, y-7, white)
+	r.canvas.DrawPixel(x+5, y-8, fur) // Little paw
+}
+
 // updateTrailParticles updates trail particle positions and lifetimes
 func (r *Renderer) updateTrailParticles() {
-	dt := rl.GetFrameTime()
+	dt := renderFrameTime()
 	alive := r.trailParticles[:0]
 	for i := range r.trailParticles {
 		p := &r.trailParticles[i]
@@ -585,9 +657,9 @@ func (r *Renderer) drawTrailParticles() {
 		alpha := uint8(float32(p.Color.A) * (p.Life / p.MaxLife))
 		color := rl.Color{R: p.Color.R, G: p.Color.G, B: p.Color.B, A: alpha}
 		if p.Size <= 1 {
-			rl.DrawPixel(int32(p.X), int32(p.Y), color)
+			r.canvas.DrawPixel(int32(p.X), int32(p.Y), color)
 		} else {
-			rl.DrawCircle(int32(p.X), int32(p.Y), p.Size, color)
+			r.canvas.DrawCircle(int32(p.X), int32(p.Y), p.Size, color)
 		}
 	}
 }
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"path/filepath"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Dungeon map layout
+const (
+	dungeonTop     = 22
+	dungeonBottom  = screenHeight - 16
+	dungeonLabelH  = 9 // Strip at the top of a room that holds its name
+	dungeonMinRoom = 6
+)
+
+// dungeonItem is a room to lay out, or the floor a room keeps for its own files
+type dungeonItem struct {
+	name   string
+	floor  bool // The floor of room name, beside its subrooms
+	weight float64
+}
+
+// dungeonTree is the rooms of a dungeon, each with its subrooms and size
+type dungeonTree struct {
+	children map[string][]string
+	size     map[string]float64 // Files in the room and everything under it
+}
+
+// buildDungeonTree links every room to its parent and sizes it
+func buildDungeonTree(d *Dungeon) dungeonTree {
+	t := dungeonTree{children: make(map[string][]string), size: make(map[string]float64)}
+	for name := range d.allRooms() {
+		if name != "." {
+			parent := dungeonRoomOf(name)
+			t.children[parent] = append(t.children[parent], name)
+		}
+	}
+	for _, kids := range t.children {
+		sort.Strings(kids)
+	}
+	var size func(name string) float64
+	size = func(name string) float64 {
+		total := 1 + float64(d.files[name])
+		for _, kid := range t.children[name] {
+			total += size(kid)
+		}
+		t.size[name] = total
+		return total
+	}
+	size(".")
+	return t
+}
+
+// ToggleDungeonMap opens or closes the dungeon map
+func (r *Renderer) ToggleDungeonMap() {
+	r.dungeonMap = !r.dungeonMap
+}
+
+// IsDungeonMapOpen returns whether the dungeon map is open
+func (r *Renderer) IsDungeonMapOpen() bool {
+	return r.dungeonMap
+}
+
+// UpdateDungeonMapAnim fades the dungeon map in and out
+func (r *Renderer) UpdateDungeonMapAnim(dt float32) {
+	if r.dungeonMap {
+		r.dungeonMapAnim = float32(math.Min(float64(r.dungeonMapAnim+dt*6), 1))
+	} else {
+		r.dungeonMapAnim = float32(math.Max(float64(r.dungeonMapAnim-dt*6), 0))
+	}
+}
+
+// DrawDungeonMap draws the project as rooms: dark until Claude explores them,
+// with loot where files were edited and scorch marks where things failed
+func (r *Renderer) DrawDungeonMap(d *Dungeon) {
+	if r.dungeonMapAnim <= 0 {
+		return
+	}
+	t := r.dungeonMapAnim
+	canvas := r.canvas
+	r.canvas = fadedCanvas{Canvas: canvas, alpha: t * (2 - t)}
+	defer func() { r.canvas = canvas }()
+
+	r.canvas.DrawRectangle(0, 0, screenWidth, screenHeight, rl.Color{R: 12, G: 10, B: 18, A: 240})
+	title := rl.Color{R: 255, G: 220, B: 150, A: 255}
+	text := rl.Color{R: 180, G: 170, B: 200, A: 255}
+	hint := rl.Color{R: 120, G: 115, B: 150, A: 255}
+	r.canvas.DrawText("DUNGEON", 8, 8, 10, title)
+	r.canvas.DrawText("D to close", screenWidth-8-r.canvas.MeasureText("D to close", 6), screenHeight-11, 6, hint)
+
+	if d == nil {
+		r.canvas.DrawText("No project explored yet", 8, dungeonTop+4, 6, text)
+		return
+	}
+	labels := r.privacy == nil || r.privacy.Mode != PrivacyCategories
+	if labels {
+		r.canvas.DrawText(filepath.Base(d.Project), 62, 10, 6, text)
+	}
+	explored, total := d.Explored()
+	stats := fmt.Sprintf("%d/%d rooms explored", explored, total)
+	r.canvas.DrawText(stats, screenWidth-8-r.canvas.MeasureText(stats, 6), 10, 6, text)
+
+	tree := buildDungeonTree(d)
+	r.drawDungeonRoom(d, tree, ".", 8, dungeonTop, screenWidth-16, dungeonBottom-dungeonTop, labels)
+}
+
+// drawDungeonRoom draws a room and lays its subrooms out inside it
+func (r *Renderer) drawDungeonRoom(d *Dungeon, tree dungeonTree, name string, x, y, w, h float32, labels bool) {
+	room := d.Rooms[name]
+	visited := room != nil && room.Reads+room.Edits > 0
+	rx, ry, rw, rh := int32(x), int32(y), int32(w), int32(h)
+
+	// Fog until Claude has been in, then torchlight that grows with every visit
+	floor := rl.Color{R: 26, G: 23, B: 36, A: 255}
+	wall := rl.Color{R: 55, G: 50, B: 72, A: 255}
+	ink := rl.Color{R: 80, G: 75, B: 100, A: 255}
+	if visited {
+		light := float32(math.Min(math.Log2(float64(room.Reads+room.Edits)+1)/6, 1))
+		floor = lerpColor(rl.Color{R: 70, G: 58, B: 48, A: 255}, rl.Color{R: 150, G: 122, B: 82, A: 255}, light)
+		wall = rl.Color{R: 175, G: 150, B: 110, A: 255}
+		ink = rl.Color{R: 250, G: 235, B: 200, A: 255}
+	}
+	r.canvas.DrawRectangle(rx, ry, rw, rh, floor)
+	r.canvas.DrawRectangleLines(rx, ry, rw, rh, wall)
+
+	// The room's name, if it fits
+	top := y + 1
+	if labels && h >= dungeonLabelH+dungeonMinRoom {
+		label := filepath.Base(name)
+		if name == "." {
+			label = filepath.Base(d.Project)
+		}
+		if r.canvas.MeasureText(label, 6)+4 <= rw {
+			r.canvas.DrawText(label, rx+2, ry+2, 6, ink)
+			top = y + dungeonLabelH
+		}
+	}
+
+	// Subrooms share the floor with the room's own files
+	kids := tree.children[name]
+	if len(kids) == 0 || w < 2*dungeonMinRoom || y+h-1-top < dungeonMinRoom {
+		r.drawDungeonFloor(d, name, rx, int32(top), rw, ry+rh-int32(top))
+		return
+	}
+	items := make([]dungeonItem, 0, len(kids)+1)
+	for _, kid := range kids {
+		items = append(items, dungeonItem{name: kid, weight: math.Sqrt(tree.size[kid])})
+	}
+	if own := d.files[name]; own > 0 || room != nil {
+		items = append(items, dungeonItem{name: name, floor: true, weight: math.Sqrt(float64(own + 1))})
+	}
+	r.layoutDungeon(d, tree, items, x+2, top+1, w-4, y+h-top-3, labels)
+}
+
+// drawDungeonFloor marks the floor of a room: loot, scorch marks and Claude
+func (r *Renderer) drawDungeonFloor(d *Dungeon, name string, x, y, w, h int32) {
+	if room := d.Rooms[name]; room != nil {
+		r.drawDungeonMarks(name, room, x, y, w, h)
+	}
+	if name == d.current && simpleSinF(renderTime()*6) > -0.3 {
+		r.canvas.DrawRectangle(x+w/2-2, y+h/2-2, 4, 4, rl.Color{R: 217, G: 119, B: 87, A: 255})
+	}
+}
+
+// layoutDungeon splits an area between rooms by size, cutting across the
+// longer side each time so rooms stay roughly square
+func (r *Renderer) layoutDungeon(d *Dungeon, tree dungeonTree, items []dungeonItem, x, y, w, h float32, labels bool) {
+	if len(items) == 0 || w < dungeonMinRoom || h < dungeonMinRoom {
+		return
+	}
+	if len(items) == 1 {
+		if items[0].floor {
+			r.drawDungeonFloor(d, items[0].name, int32(x), int32(y), int32(w), int32(h))
+		} else {
+			r.drawDungeonRoom(d, tree, items[0].name, x, y, w, h, labels)
+		}
+		return
+	}
+
+	total := 0.0
+	for _, item := range items {
+		total += item.weight
+	}
+	split, first := 1, items[0].weight
+	for split < len(items)-1 && first+items[split].weight/2 < total/2 {
+		first += items[split].weight
+		split++
+	}
+	k := float32(first / total)
+	if w >= h {
+		cut := float32(math.Round(float64(w * k)))
+		r.layoutDungeon(d, tree, items[:split], x, y, cut-1, h, labels)
+		r.layoutDungeon(d, tree, items[split:], x+cut+1, y, w-cut-1, h, labels)
+	} else {
+		cut := float32(math.Round(float64(h * k)))
+		r.layoutDungeon(d, tree, items[:split], x, y, w, cut-1, labels)
+		r.layoutDungeon(d, tree, items[split:], x, y+cut+1, w, h-cut-1, labels)
+	}
+}
+
+// drawDungeonMarks leaves loot where files were edited and scorch marks
+// where things went wrong, scattered the same way every time
+func (r *Renderer) drawDungeonMarks(name string, room *DungeonRoom, x, y, w, h int32) {
+	if w < dungeonMinRoom+2 || h < dungeonMinRoom+2 {
+		return
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	seed := hash.Sum64()
+	spot := func() (int32, int32) {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		return x + 2 + int32(seed>>34)%(w-6), y + 2 + int32(seed>>17&0xffff)%(h-5)
+	}
+
+	scorches := room.Errors
+	if scorches > 4 {
+		scorches = 4
+	}
+	for i := 0; i < scorches; i++ {
+		sx, sy := spot()
+		r.canvas.DrawRectangle(sx, sy+1, 4, 1, rl.Color{R: 20, G: 12, B: 10, A: 200})
+		r.canvas.DrawRectangle(sx+1, sy, 2, 3, rl.Color{R: 20, G: 12, B: 10, A: 200})
+		r.canvas.DrawPixel(sx+1, sy+1, rl.Color{R: 160, G: 60, B: 20, A: 200})
+	}
+
+	loot := 0
+	switch {
+	case room.Edits >= 20:
+		loot = 3
+	case room.Edits >= 5:
+		loot = 2
+	case room.Edits > 0:
+		loot = 1
+	}
+	for i := int32(0); i < int32(loot) && 5*(i+1) < w; i++ {
+		lx, ly := x+w-6-5*i, y+h-5
+		r.canvas.DrawRectangle(lx, ly, 4, 3, rl.Color{R: 140, G: 90, B: 45, A: 255})
+		r.canvas.DrawRectangle(lx, ly, 4, 1, rl.Color{R: 255, G: 215, B: 80, A: 255})
+	}
+}
@@ -2,7 +2,7 @@ package main
 
 import (
 	"math"
-	"math/rand"
+	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -73,7 +73,7 @@ func (r *Renderer) drawShippedBanner(state *GameState) {
 		c.A = alpha
 
 		// Draw the trail text (single color per trail)
-		rl.DrawText(text, trailX, trailY, fontSize, c)
+		r.canvas.DrawText(text, trailX, trailY, fontSize, c)
 	}
 
 	// Get main text position
@@ -88,12 +88,12 @@ func (r *Renderer) drawShippedBanner(state *GameState) {
 	for dx := int32(-2); dx <= 2; dx++ {
 		for dy := int32(-2); dy <= 2; dy++ {
 			if dx != 0 || dy != 0 {
-				rl.DrawText(text, x+dx, y+dy, fontSize, black)
+				r.canvas.DrawText(text, x+dx, y+dy, fontSize, black)
 			}
 		}
 	}
 	// White fill
-	rl.DrawText(text, x, y, fontSize, white)
+	r.canvas.DrawText(text, x, y, fontSize, white)
 }
 
 // drawThinkHardEffect renders firework-style particle effects
@@ -147,7 +147,7 @@ func (r *Renderer) drawThinkHardEffect(state *GameState) {
 	if isUltra {
 		fontSize = 10
 	}
-	textWidth := rl.MeasureText(burstText, fontSize)
+	textWidth := r.canvas.MeasureText(burstText, fontSize)
 
 	// Subtle pulsing glow behind text
 	pulse := float32(1.0) + float32(0.1*simpleSinF(float64(state.ThinkHardTimer*6)))
@@ -163,7 +163,7 @@ func (r *Renderer) drawThinkHardEffect(state *GameState) {
 		glowColor = hsvToRGB(hue, 0.6, 1.0)
 	}
 	glowColor.A = 150
-	rl.DrawRectangle(glowX, glowY, glowW, glowH, glowColor)
+	r.canvas.DrawRectangle(glowX, glowY, glowW, glowH, glowColor)
 
 	// Draw text
 	textX := cx - textWidth/2
@@ -171,22 +171,22 @@ func (r *Renderer) drawThinkHardEffect(state *GameState) {
 
 	// Shadow for all
 	shadowColor := rl.Color{R: 20, G: 15, B: 30, A: 180}
-	rl.DrawText(burstText, textX+1, textY+1, fontSize, shadowColor)
+	r.canvas.DrawText(burstText, textX+1, textY+1, fontSize, shadowColor)
 
 	if isUltra {
 		// Cycling bright color for ULTRATHINK
 		hue := int(state.ThinkHardTimer*300) % 360
 		textColor := hsvToRGB(hue, 1.0, 1.0)
-		rl.DrawText(burstText, textX, textY, fontSize, textColor)
+		r.canvas.DrawText(burstText, textX, textY, fontSize, textColor)
 	} else {
-		rl.DrawText(burstText, textX, textY, fontSize, baseColor)
+		r.canvas.DrawText(burstText, textX, textY, fontSize, baseColor)
 	}
 }
 
 // spawnThinkParticles creates firework-style particles for thinking effect
 func (r *Renderer) spawnThinkParticles(cx, cy, intensity float32, isUltra bool, timer float32) {
 	// Spawn rate based on intensity
-	if rand.Float32() > intensity {
+	if r.rng.Float32() > intensity {
 		return
 	}
 
@@ -198,10 +198,10 @@ func (r *Renderer) spawnThinkParticles(cx, cy, intensity float32, isUltra bool,
 
 	for i := 0; i < numParticles; i++ {
 		// Random angle for burst direction
-		angle := rand.Float32() * 6.28318 // 2*PI
+		angle := r.rng.Float32() * 6.28318 // 2*PI
 
 		// Velocity based on angle
-		speed := float64(20 + rand.Float32()*40)
+		speed := float64(20 + r.rng.Float32()*40)
 		vx := speed * simpleCosF(float64(angle))
 		vy := speed * simpleSinF(float64(angle))
 
@@ -209,7 +209,7 @@ func (r *Renderer) spawnThinkParticles(cx, cy, intensity float32, isUltra bool,
 		var color rl.Color
 		if isUltra {
 			// Rainbow colors
-			hue := (int(timer*200) + rand.Intn(120)) % 360
+			hue := (int(timer*200) + r.rng.Intn(120)) % 360
 			color = hsvToRGB(hue, 1.0, 1.0)
 		} else {
 			// Warm colors - yellows, oranges, whites
@@ -219,34 +219,34 @@ func (r *Renderer) spawnThinkParticles(cx, cy, intensity float32, isUltra bool,
 				{R: 255, G: 180, B: 80, A: 255},  // Orange
 				{R: 255, G: 200, B: 150, A: 255}, // Light orange
 			}
-			color = colors[rand.Intn(len(colors))]
+			color = colors[r.rng.Intn(len(colors))]
 		}
 
 		// Spawn particle near center with some spread
-		px := cx + (rand.Float32()-0.5)*10
-		py := cy + (rand.Float32()-0.5)*10
+		px := cx + (r.rng.Float32()-0.5)*10
+		py := cy + (r.rng.Float32()-0.5)*10
 
 		r.particles = append(r.particles, Particle{
 			X:       px,
 			Y:       py,
 			VX:      float32(vx),
 			VY:      float32(vy),
-			Life:    0.4 + rand.Float32()*0.4,
+			Life:    0.4 + r.rng.Float32()*0.4,
 			MaxLife: 0.8,
 			Color:   color,
-			Size:    1 + rand.Float32()*2,
+			Size:    1 + r.rng.Float32()*2,
 		})
 	}
 
 	// Extra trailing sparkles for ultra
-	if isUltra && rand.Float32() < 0.3 {
+	if isUltra && r.rng.Float32() < 0.3 {
 		// Spawn a larger, slower sparkle
 		hue := int(timer*300) % 360
 		r.particles = append(r.particles, Particle{
-			X:       cx + (rand.Float32()-0.5)*30,
-			Y:       cy + (rand.Float32()-0.5)*20,
-			VX:      (rand.Float32() - 0.5) * 10,
-			VY:      -5 - rand.Float32()*10,
+			X:       cx + (r.rng.Float32()-0.5)*30,
+			Y:       cy + (r.rng.Float32()-0.5)*20,
+			VX:      (r.rng.Float32() - 0.5) * 10,
+			VY:      -5 - r.rng.Float32()*10,
 			Life:    0.8,
 			MaxLife: 0.8,
 			Color:   hsvToRGB(hue, 1.0, 1.0),
@@ -268,17 +268,54 @@ func (r *Renderer) drawCompactEffect(state *GameState) {
 	zColor := rl.Color{R: 180, G: 180, B: 220, A: alpha}
 
 	// Draw multiple Z's at different sizes
-	rl.DrawText("z", zx, zy, 8, zColor)
-	rl.DrawText("z", zx+8, zy-6, 10, zColor)
-	rl.DrawText("Z", zx+18, zy-14, 12, zColor)
+	r.canvas.DrawText("z", zx, zy, 8, zColor)
+	r.canvas.DrawText("z", zx+8, zy-6, 10, zColor)
+	r.canvas.DrawText("Z", zx+18, zy-14, 12, zColor)
 
 	// Draw "REST" text
 	if progress < 0.5 {
 		restAlpha := uint8((0.5 - progress) * 2 * 200)
 		restColor := rl.Color{R: 100, G: 180, B: 100, A: restAlpha}
 		restText := "MANA RESTORED"
-		restWidth := rl.MeasureText(restText, 8)
-		rl.DrawText(restText, (screenWidth-restWidth)/2, 45, 8, restColor)
+		restWidth := r.canvas.MeasureText(restText, 8)
+		r.canvas.DrawText(restText, (screenWidth-restWidth)/2, 45, 8, restColor)
+	}
+}
+
+// drawRestingEffect renders the resting-at-the-inn overlay while rate limited
+func (r *Renderer) drawRestingEffect(state *GameState) {
+	t := float32(renderTime())
+
+	// Warm lamplight over the scene
+	r.canvas.DrawRectangle(0, 0, screenWidth, screenHeight, rl.Color{R: 40, G: 20, B: 10, A: 110})
+
+	// Hanging inn sign
+	signW := int32(70)
+	signX := int32(screenWidth/2) - signW/2
+	signY := int32(28)
+	woodDark := rl.Color{R: 70, G: 40, B: 20, A: 255}
+	wood := rl.Color{R: 130, G: 80, B: 40, A: 255}
+	r.canvas.DrawRectangle(signX+8, signY-8, 1, 8, woodDark)
+	r.canvas.DrawRectangle(signX+signW-9, signY-8, 1, 8, woodDark)
+	r.canvas.DrawRectangle(signX-1, signY-1, signW+2, 14, woodDark)
+	r.canvas.DrawRectangle(signX, signY, signW, 12, wood)
+	innText := "THE INN"
+	innWidth := r.canvas.MeasureText(innText, 8)
+	r.canvas.DrawText(innText, signX+(signW-innWidth)/2, signY+2, 8, rl.Color{R: 255, G: 220, B: 140, A: 255})
+
+	// Countdown until stamina returns
+	restText := "Resting - back in " + formatCountdown(time.Until(state.Usage.LimitedUntil))
+	restWidth := r.canvas.MeasureText(restText, 8)
+	r.canvas.DrawText(restText, (screenWidth-restWidth)/2+1, signY+19, 8, rl.Color{R: 0, G: 0, B: 0, A: 150})
+	r.canvas.DrawText(restText, (screenWidth-restWidth)/2, signY+18, 8, rl.Color{R: 220, G: 200, B: 170, A: 255})
+
+	// Zzz drifting up on a loop
+	for i := 0; i < 3; i++ {
+		phase := float32(math.Mod(float64(t*0.5+float32(i)/3), 1))
+		alpha := uint8((1 - phase) * 220)
+		zx := int32(screenWidth/2 + 20 + int32(phase*12))
+		zy := int32(85 - phase*25)
+		r.canvas.DrawText("z", zx, zy, 8+int32(i)*2, rl.Color{R: 180, G: 180, B: 220, A: alpha})
 	}
 }
 
@@ -329,7 +366,7 @@ func (r *Renderer) spawnChestParticles(cx, cy float32, intense bool) {
 		spawnChance = 0.6
 	}
 
-	if rand.Float32() > spawnChance {
+	if r.rng.Float32() > spawnChance {
 		return
 	}
 
@@ -341,10 +378,10 @@ func (r *Renderer) spawnChestParticles(cx, cy float32, intense bool) {
 
 	for i := 0; i < numParticles; i++ {
 		// Random angle for burst direction (upward bias)
-		angle := rand.Float32()*3.14159 + 3.14159/2 // PI/2 to 3PI/2 (upward half)
+		angle := r.rng.Float32()*3.14159 + 3.14159/2 // PI/2 to 3PI/2 (upward half)
 
 		// Velocity
-		speed := float64(15 + rand.Float32()*25)
+		speed := float64(15 + r.rng.Float32()*25)
 		vx := speed * simpleCosF(float64(angle))
 		vy := speed * simpleSinF(float64(angle))
 
@@ -355,21 +392,21 @@ func (r *Renderer) spawnChestParticles(cx, cy float32, intense bool) {
 			{R: 255, G: 200, B: 100, A: 255}, // Orange-gold
 			{R: 255, G: 255, B: 255, A: 255}, // White sparkle
 		}
-		color := colors[rand.Intn(len(colors))]
+		color := colors[r.rng.Intn(len(colors))]
 
 		// Spawn from chest area
-		px := cx + (rand.Float32()-0.5)*40
-		py := cy + (rand.Float32()-0.5)*20
+		px := cx + (r.rng.Float32()-0.5)*40
+		py := cy + (r.rng.Float32()-0.5)*20
 
 		r.particles = append(r.particles, Particle{
 			X:       px,
 			Y:       py,
 			VX:      float32(vx),
 			VY:      float32(vy),
-			Life:    0.5 + rand.Float32()*0.5,
+			Life:    0.5 + r.rng.Float32()*0.5,
 			MaxLife: 1.0,
 			Color:   color,
-			Size:    1 + rand.Float32()*2,
+			Size:    1 + r.rng.Float32()*2,
 		})
 	}
 }
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"image"
+	"math"
+
+	"claude-quest/sprites"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Where the face sits in a sprite frame before the head offset
+const (
+	faceEyeTop    = 13
+	faceLeftEye   = 11
+	faceRightEye  = 18
+	faceMouthTop  = 18
+	faceCenterX   = 16
+	faceBlinkTime = 0.25 // Seconds a blink takes
+	faceBlinkGap  = 4    // Seconds between blinks
+	faceYawnGap   = 8    // Seconds between yawns
+)
+
+// Body colors the baked eyes are painted over with
+var (
+	faceHighlight = rl.Color{R: 0xFF, G: 0xBB, B: 0x77, A: 255}
+	faceBody      = rl.Color{R: 0xFF, G: 0x99, B: 0x33, A: 255}
+)
+
+// expression is the eyes and mouth an emotion puts on
+type expression struct {
+	eyes  sprites.EyeState
+	mouth sprites.MouthState
+}
+
+var expressions = map[Emotion]expression{
+	EmotionFocused:    {sprites.EyeHalfClosed, sprites.MouthNone},
+	EmotionHappy:      {sprites.EyeHappy, sprites.MouthSmile},
+	EmotionFrustrated: {sprites.EyeSquint, sprites.MouthLine},
+	EmotionSleepy:     {sprites.EyeClosed, sprites.MouthOpen},
+	EmotionProud:      {sprites.EyeClosed, sprites.MouthSmile},
+	EmotionAnxious:    {sprites.EyeOpen, sprites.MouthOpen},
+}
+
+// faceParts caches the eyes and mouths from the sprite generators
+type faceParts struct {
+	eyes   map[sprites.EyeState]*image.RGBA
+	mouths map[sprites.MouthState]*image.RGBA
+}
+
+// eye returns the generated eye for a state
+func (f *faceParts) eye(state sprites.EyeState) *image.RGBA {
+	if f.eyes == nil {
+		f.eyes = make(map[sprites.EyeState]*image.RGBA)
+	}
+	if _, ok := f.eyes[state]; !ok {
+		f.eyes[state] = sprites.GenerateEyeTexture(state)
+	}
+	return f.eyes[state]
+}
+
+// mouth returns the generated mouth for a state (nil for none)
+func (f *faceParts) mouth(state sprites.MouthState) *image.RGBA {
+	if f.mouths == nil {
+		f.mouths = make(map[sprites.MouthState]*image.RGBA)
+	}
+	if _, ok := f.mouths[state]; !ok {
+		f.mouths[state] = sprites.GenerateMouthTexture(state)
+	}
+	return f.mouths[state]
+}
+
+// drawEmotion puts the strongest feeling on Claude's face, over whatever
+// face the body animation has
+func (r *Renderer) drawEmotion(state *AnimationState) {
+	emotion := r.mood.Dominant()
+	if emotion == EmotionNeutral || !r.hasSprites || state.CurrentAnim == AnimEnter {
+		return
+	}
+	headOffX, headOffY := getHeadOffset(state)
+	if headOffY < -50 {
+		return
+	}
+
+	// Top left of the sprite frame on screen, in sprite pixels
+	scaledW := float32(spriteFrameWidth * claudeScale)
+	scaledH := float32(spriteFrameHeight * claudeScale)
+	ox := (float32(screenWidth/2)-scaledW/2)/claudeScale + headOffX
+	oy := (float32(160)-scaledH+10)/claudeScale + headOffY
+
+	// Paint over the eyes baked into the frame
+	r.drawSpritePixels(ox+faceLeftEye-1, oy+faceEyeTop, 12, 1, faceHighlight)
+	r.drawSpritePixels(ox+faceLeftEye-1, oy+faceEyeTop+1, 12, 4, faceBody)
+
+	face := expressions[emotion]
+	eyes := face.eyes
+	if eyes == sprites.EyeOpen {
+		// Blink now and then
+		t := math.Mod(renderTime(), faceBlinkGap)
+		if t < faceBlinkTime {
+			frames := sprites.BlinkFrames()
+			eyes = frames[int(t/faceBlinkTime*float64(len(frames)))]
+		}
+	}
+	mouth := face.mouth
+	if emotion == EmotionSleepy && math.Mod(renderTime(), faceYawnGap) < 1.5 {
+		mouth = sprites.MouthWide
+	}
+
+	r.drawFacePart(r.face.eye(eyes), ox+faceLeftEye, oy+faceEyeTop, false)
+	r.drawFacePart(r.face.eye(eyes), ox+faceRightEye, oy+faceEyeTop, true)
+	if img := r.face.mouth(mouth); img != nil {
+		r.drawFacePart(img, ox+faceCenterX-float32(img.Bounds().Dx()/2), oy+faceMouthTop, false)
+	}
+
+	// A bead of sweat when worried
+	if emotion == EmotionAnxious {
+		drip := float32(math.Mod(renderTime()*2, 3))
+		r.drawSpritePixels(ox+23, oy+faceEyeTop-2+drip, 1, 2, rl.Color{R: 120, G: 190, B: 255, A: 255})
+	}
+}
+
+// drawFacePart draws a generated image over Claude a sprite pixel at a time,
+// mirrored for the right eye so "> <" faces inwards
+func (r *Renderer) drawFacePart(img *image.RGBA, x, y float32, mirror bool) {
+	b := img.Bounds()
+	for py := b.Min.Y; py < b.Max.Y; py++ {
+		for px := b.Min.X; px < b.Max.X; px++ {
+			c := img.RGBAAt(px, py)
+			if c.A == 0 {
+				continue
+			}
+			dx := px - b.Min.X
+			if mirror {
+				dx = b.Dx() - 1 - dx
+			}
+			r.drawSpritePixels(x+float32(dx), y+float32(py-b.Min.Y), 1, 1, rl.Color{R: c.R, G: c.G, B: c.B, A: c.A})
+		}
+	}
+}
+
+// drawSpritePixels fills a block of sprite pixels at Claude's scale
+func (r *Renderer) drawSpritePixels(x, y float32, w, h int32, color rl.Color) {
+	r.canvas.DrawRectangle(int32(x*claudeScale), int32(y*claudeScale), w*claudeScale, h*claudeScale, color)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Golden frames render named scenes on the software canvas with a fixed seed
+// and timestep, and compare them to PNGs in testdata/golden. After an
+// intentional art change, rewrite them with:
+//
+//	go test -run TestGoldenFrames -update
+var updateGolden = flag.Bool("update", false, "rewrite golden frames instead of comparing")
+
+const (
+	goldenSeed      = 1
+	goldenStep      = float32(1.0 / 30)
+	goldenTolerance = 8     // Per-channel difference that still counts as the same
+	goldenMaxDiff   = 0.002 // Fraction of pixels allowed to differ
+)
+
+// goldenClock is a summer noon: no night tint and no seasonal weather
+var goldenClock = time.Date(2025, time.June, 15, 12, 0, 0, 0, time.Local)
+
+// goldenScene is one frame to check: set up the game, play it for a while
+type goldenScene struct {
+	name  string
+	at    float32 // Seconds to play before the frame is taken
+	setup func(r *Renderer, a *AnimationSystem, g *GameState)
+}
+
+var goldenScenes = []goldenScene{
+	{name: "biome-forest", at: 2, setup: biomeScene(0)},
+	{name: "biome-mountain", at: 2, setup: biomeScene(1)},
+	{name: "biome-midnight", at: 2, setup: biomeScene(2)},
+	{name: "biome-kingdom", at: 2, setup: biomeScene(3)},
+	{name: "biome-library", at: 2, setup: biomeScene(4)},
+	{name: "shipped-banner", at: 1, setup: eventScene(Event{Type: EventGitPush})},
+	{name: "think-hard", at: 1.5, setup: eventScene(Event{Type: EventThinkHard, Details: "Ultrathinking...", ThinkLevel: ThinkUltra})},
+	{name: "thought-bubble", at: 1, setup: eventScene(Event{Type: EventThinking, ThoughtText: "The login bug is in the session cookie path"})},
+	{name: "quest-text", at: 1, setup: eventScene(Event{Type: EventQuest, Details: "Fix the login bug"})},
+	{name: "compact", at: 1, setup: eventScene(Event{Type: EventCompact})},
+	{name: "rain", at: 1.5, setup: eventScene(
+		Event{Type: EventBash, ToolName: "Bash", ToolUseID: "t1", TestRun: true},
+		Event{Type: EventError, ToolUseID: "t1", Details: "FAIL TestLogin"},
+	)},
+	{name: "storm", at: 1.5, setup: eventScene(
+		Event{Type: EventError, Details: "undefined: session"},
+		Event{Type: EventError, Details: "undefined: session"},
+		Event{Type: EventError, Details: "undefined: session"},
+	)},
+	{name: "frustrated", at: 1.5, setup: eventScene(
+		Event{Type: EventError, Details: "permission denied"},
+		Event{Type: EventError, Details: "file not found"},
+	)},
+}
+
+// biomeScene walks through a biome with nothing else going on
+func biomeScene(biome int) func(r *Renderer, a *AnimationSystem, g *GameState) {
+	return func(r *Renderer, a *AnimationSystem, g *GameState) {
+		r.SetBiome(biome)
+		a.HandleEvent(Event{Type: EventWriting})
+		g.HandleEvent(Event{Type: EventWriting})
+		r.HandleEvent(Event{Type: EventWriting})
+	}
+}
+
+// eventScene plays events in the first biome, all at the start
+func eventScene(events ...Event) func(r *Renderer, a *AnimationSystem, g *GameState) {
+	return func(r *Renderer, a *AnimationSystem, g *GameState) {
+		for _, event := range events {
+			a.HandleEvent(event)
+			g.HandleEvent(event)
+			r.HandleEvent(event)
+		}
+	}
+}
+
+// renderGoldenScene plays a scene from a clean start and returns its frame
+func renderGoldenScene(t *testing.T, scene goldenScene) *image.RGBA {
+	// No saved accessories or profile from the machine running the tests
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	config := &Config{}
+	profile := newEmptyProfile()
+	profile.Level = 3
+	profile.XP = XPForLevel(3) // So a scene's XP doesn't recompute the level
+	profile.scratch = true
+
+	canvas := NewImageCanvas(screenWidth, screenHeight)
+	renderer := NewRendererOn(config, canvas)
+	defer renderer.Unload()
+	renderer.SetProfile(profile)
+	renderer.Seed(goldenSeed)
+	renderer.SetClock(func() time.Time { return goldenClock })
+	animations := NewAnimationSystem()
+	gameState := newGameState(config, profile)
+
+	fixedFrameTime = goldenStep
+	fixedTime = 0
+	randSeed = 12345 // Enemies spawn the same way whichever scenes ran before
+	defer func() { fixedFrameTime = 0 }()
+
+	scene.setup(renderer, animations, gameState)
+	for elapsed := float32(0); elapsed < scene.at; elapsed += goldenStep {
+		animations.Update(goldenStep)
+		gameState.Update(goldenStep)
+		renderer.Update(goldenStep)
+		animations.SetActive(gameState.IsActive)
+		if gameState.IsActive {
+			renderer.UpdateScroll(goldenStep)
+		}
+
+		// Every frame is drawn - particles and trails update as they draw
+		canvas.Clear(sceneBackground)
+		renderer.Draw(animations.GetState())
+		renderer.DrawGameUI(gameState)
+		stepRenderClock()
+	}
+	return scaleFrame(canvas.Image, 1)
+}
+
+func TestGoldenFrames(t *testing.T) {
+	for _, scene := range goldenScenes {
+		t.Run(scene.name, func(t *testing.T) {
+			got := renderGoldenScene(t, scene)
+			path := filepath.Join("testdata", "golden", scene.name+".png")
+
+			if *updateGolden {
+				if err := writePNG(path, got); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := readPNG(path)
+			if err != nil {
+				t.Fatalf("%v (run go test -run TestGoldenFrames -update to create it)", err)
+			}
+			if diff := countDiffPixels(got, want); float64(diff) > goldenMaxDiff*float64(screenWidth*screenHeight) {
+				actual := filepath.Join(os.TempDir(), "claude-quest-golden", scene.name+".png")
+				writePNG(actual, got)
+				t.Errorf("%d pixels differ from %s (actual frame saved to %s)", diff, path, actual)
+			}
+		})
+	}
+}
+
+// countDiffPixels counts pixels that differ by more than the tolerance
+func countDiffPixels(got, want *image.RGBA) int {
+	if got.Rect.Size() != want.Rect.Size() {
+		return got.Rect.Dx() * got.Rect.Dy()
+	}
+	diff := 0
+	for i := 0; i < len(got.Pix); i += 4 {
+		for c := 0; c < 4; c++ {
+			d := int(got.Pix[i+c]) - int(want.Pix[i+c])
+			if d > goldenTolerance || d < -goldenTolerance {
+				diff++
+				break
+			}
+		}
+	}
+	return diff
+}
+
+// readPNG loads a PNG as RGBA
+func readPNG(path string) (*image.RGBA, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	rgba := image.NewRGBA(img.Bounds())
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			rgba.Set(x, y, img.At(x, y))
+		}
+	}
+	return rgba, nil
+}
+
+// writePNG saves a frame, creating the directory if needed
+func writePNG(path string, img *image.RGBA) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -19,7 +19,7 @@ func (r *Renderer) drawMountain(x, baseY, width, height int32, color rl.Color) {
 		// Width at this row
 		w := width * (height - row) / height
 		startX := x - w/2
-		rl.DrawRectangle(startX, baseY-row, w, 1, color)
+		r.canvas.DrawRectangle(startX, baseY-row, w, 1, color)
 	}
 }
 
@@ -30,7 +30,7 @@ func (r *Renderer) drawHill(x, baseY, width, height int32, color rl.Color) {
 		t := float32(row) / float32(height)
 		w := int32(float32(width) * (1 - t*t))
 		startX := x - w/2
-		rl.DrawRectangle(startX, baseY-row, w, 1, color)
+		r.canvas.DrawRectangle(startX, baseY-row, w, 1, color)
 	}
 }
 
@@ -38,7 +38,7 @@ func (r *Renderer) drawHill(x, baseY, width, height int32, color rl.Color) {
 func (r *Renderer) drawTree(x, baseY, height int32, color rl.Color) {
 	// Trunk
 	trunkColor := rl.Color{R: 60, G: 45, B: 35, A: 255}
-	rl.DrawRectangle(x, baseY-height/3, 2, height/3, trunkColor)
+	r.canvas.DrawRectangle(x, baseY-height/3, 2, height/3, trunkColor)
 
 	// Foliage - triangle
 	for row := int32(0); row < height*2/3; row++ {
@@ -46,14 +46,14 @@ func (r *Renderer) drawTree(x, baseY, height int32, color rl.Color) {
 		if w < 1 {
 			w = 1
 		}
-		rl.DrawRectangle(x+1-w/2, baseY-height/3-row, w, 1, color)
+		r.canvas.DrawRectangle(x+1-w/2, baseY-height/3-row, w, 1, color)
 	}
 }
 
 // drawGrass draws small grass tufts
 func (r *Renderer) drawGrass(x, y int32) {
 	grassColor := rl.Color{R: 50, G: 80, B: 45, A: 255}
-	rl.DrawPixel(x, y, grassColor)
-	rl.DrawPixel(x+1, y-1, grassColor)
-	rl.DrawPixel(x+2, y, grassColor)
+	r.canvas.DrawPixel(x, y, grassColor)
+	r.canvas.DrawPixel(x+1, y-1, grassColor)
+	r.canvas.DrawPixel(x+2, y, grassColor)
 }
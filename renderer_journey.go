@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// World map layout
+const (
+	mapRoadLeft  = 24
+	mapRoadRight = screenWidth - 24
+	mapRoadY     = 100 // Middle of the winding road
+	mapRoadSwing = 26  // How far the road winds up and down
+	mapRegionTop = 34
+	mapRegionH   = 132
+)
+
+// ToggleJourneyMap opens or closes the world map
+func (r *Renderer) ToggleJourneyMap() {
+	r.journeyMap = !r.journeyMap
+}
+
+// IsJourneyMapOpen returns whether the world map is open
+func (r *Renderer) IsJourneyMapOpen() bool {
+	return r.journeyMap
+}
+
+// UpdateJourneyMapAnim fades the world map in and out
+func (r *Renderer) UpdateJourneyMapAnim(dt float32) {
+	if r.journeyMap {
+		r.journeyMapAnim = float32(math.Min(float64(r.journeyMapAnim+dt*6), 1))
+	} else {
+		r.journeyMapAnim = float32(math.Max(float64(r.journeyMapAnim-dt*6), 0))
+	}
+}
+
+// mapRoadPoint returns where a point t of the way along the road is drawn
+func (r *Renderer) mapRoadPoint(t float64) (int32, int32) {
+	x := mapRoadLeft + t*(mapRoadRight-mapRoadLeft)
+	y := mapRoadY + mapRoadSwing*math.Sin(t*float64(len(r.biomes))*math.Pi)
+	return int32(x), int32(y)
+}
+
+// DrawJourneyMap draws the world map: the biomes as regions along one road,
+// the landmarks left on it and where Claude is now
+func (r *Renderer) DrawJourneyMap() {
+	if r.journeyMapAnim <= 0 {
+		return
+	}
+	t := r.journeyMapAnim
+	alpha := t * (2 - t)
+	canvas := r.canvas
+	r.canvas = fadedCanvas{Canvas: canvas, alpha: alpha}
+	defer func() { r.canvas = canvas }()
+
+	var journey Journey
+	level := 0
+	if r.profile != nil {
+		journey = r.profile.Journey
+		level = r.profile.Level
+	}
+	regions := len(r.biomes)
+	total := float64(regions) * journeyRegionLength
+	position := journeyPosition(regions, journey.Distance, level)
+
+	r.canvas.DrawRectangle(0, 0, screenWidth, screenHeight, rl.Color{R: 16, G: 14, B: 28, A: 235})
+
+	// Regions, one per biome
+	regionW := int32((mapRoadRight - mapRoadLeft) / regions)
+	for i, pack := range r.biomes {
+		x := int32(mapRoadLeft) + int32(i)*regionW
+		open := regionOpen(i, journey.Distance, level)
+		fill := lerpColor(rl.Color(pack.Sky.Top), rl.Color(pack.Sky.Bottom), 0.5)
+		edge := rl.Color(pack.Sky.Bottom)
+		name := pack.Name
+		if !open {
+			fill = rl.Color{R: 30, G: 28, B: 42, A: 255}
+			edge = rl.Color{R: 55, G: 52, B: 70, A: 255}
+			name = "???"
+		}
+		rect := rl.Rectangle{X: float32(x + 2), Y: mapRegionTop, Width: float32(regionW - 4), Height: mapRegionH}
+		r.canvas.DrawRectangleRounded(rect, 0.2, 4, fill)
+		r.canvas.DrawRectangleRoundedLines(rect, 0.2, 4, edge)
+
+		nameW := r.canvas.MeasureText(name, 6)
+		r.canvas.DrawText(name, x+(regionW-nameW)/2, mapRegionTop+4, 6, rl.Color{R: 220, G: 215, B: 235, A: 255})
+		if !open {
+			for line, need := range strings.Split(regionRequirement(i), ", ") {
+				needW := r.canvas.MeasureText(need, 6)
+				r.canvas.DrawText(need, x+(regionW-needW)/2, mapRegionTop+mapRegionH-18+int32(line)*8, 6, rl.Color{R: 120, G: 115, B: 150, A: 255})
+			}
+		}
+	}
+
+	// The road: walked stretches in sand, the rest dotted
+	steps := int(mapRoadRight - mapRoadLeft)
+	for s := 0; s <= steps; s++ {
+		along := float64(s) / float64(steps)
+		x, y := r.mapRoadPoint(along)
+		if along*total <= position {
+			r.canvas.DrawRectangle(x, y, 1, 2, rl.Color{R: 230, G: 200, B: 140, A: 255})
+		} else if s%3 == 0 {
+			r.canvas.DrawPixel(x, y, rl.Color{R: 140, G: 130, B: 110, A: 200})
+		}
+	}
+
+	// Landmarks: a landmark left while Claude waited at a closed region sits at its edge
+	for _, landmark := range journey.Landmarks {
+		x, y := r.mapRoadPoint(math.Min(landmark.Distance, position) / total)
+		r.drawLandmark(landmark.Kind, x, y)
+	}
+
+	// Claude, bobbing along
+	x, y := r.mapRoadPoint(position / total)
+	bob := int32(simpleSinF(renderTime()*4) * 1.5)
+	pulse := 4 + 2*float32(simpleSinF(renderTime()*3))
+	r.canvas.DrawCircleLines(x, y, pulse, rl.Color{R: 255, G: 200, B: 80, A: 160})
+	r.canvas.DrawRectangle(x-3, y-5+bob, 6, 4, rl.Color{R: 217, G: 119, B: 87, A: 255})
+	r.canvas.DrawPixel(x-1, y-4+bob, rl.Color{R: 40, G: 30, B: 30, A: 255})
+	r.canvas.DrawPixel(x+1, y-4+bob, rl.Color{R: 40, G: 30, B: 30, A: 255})
+
+	// Header: distance and level
+	title := rl.Color{R: 255, G: 220, B: 150, A: 255}
+	text := rl.Color{R: 180, G: 170, B: 200, A: 255}
+	r.canvas.DrawText("WORLD MAP", 8, 8, 10, title)
+	stats := fmt.Sprintf("%s walked  Lv%d", formatDistance(journey.Distance), level)
+	r.canvas.DrawText(stats, screenWidth-8-r.canvas.MeasureText(stats, 6), 10, 6, text)
+
+	// Footer: the latest landmark and the next region
+	footer := "The road starts here"
+	if n := len(journey.Landmarks); n > 0 {
+		latest := journey.Landmarks[n-1]
+		footer = latest.Label + " at " + formatDistance(latest.Distance)
+	}
+	r.canvas.DrawText(footer, 8, screenHeight-26, 6, text)
+	for i := 1; i < regions; i++ {
+		if !regionOpen(i, journey.Distance, level) {
+			r.canvas.DrawText("Next region: "+regionRequirement(i), 8, screenHeight-16, 6, text)
+			break
+		}
+	}
+	hint := "M to close"
+	r.canvas.DrawText(hint, screenWidth-8-r.canvas.MeasureText(hint, 6), screenHeight-16, 6, rl.Color{R: 120, G: 115, B: 150, A: 255})
+}
+
+// drawLandmark draws a landmark's marker on the road
+func (r *Renderer) drawLandmark(kind string, x, y int32) {
+	switch kind {
+	case LandmarkPush:
+		// A flag
+		r.canvas.DrawLine(x, y-9, x, y, rl.Color{R: 200, G: 190, B: 170, A: 255})
+		r.canvas.DrawRectangle(x+1, y-9, 4, 3, rl.Color{R: 90, G: 200, B: 110, A: 255})
+	case LandmarkLevel:
+		// A star
+		gold := rl.Color{R: 255, G: 215, B: 80, A: 255}
+		r.canvas.DrawRectangle(x-1, y-6, 3, 3, gold)
+		r.canvas.DrawPixel(x, y-8, gold)
+		r.canvas.DrawPixel(x, y-2, gold)
+		r.canvas.DrawPixel(x-3, y-5, gold)
+		r.canvas.DrawPixel(x+3, y-5, gold)
+	case LandmarkChest:
+		// A little chest
+		r.canvas.DrawRectangle(x-2, y-5, 5, 4, rl.Color{R: 140, G: 90, B: 45, A: 255})
+		r.canvas.DrawPixel(x, y-4, rl.Color{R: 255, G: 215, B: 80, A: 255})
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ============================================================================
+// MILESTONES
+// ============================================================================
+//
+// Every commit plants a signpost by the path with its message on it, and every
+// push a flag - a monument when the push carries a commit of its own. They go
+// up just ahead of Claude and scroll past as the walk goes on. The session
+// keeps all of them, and since they come from the transcript, a replay plants
+// the same ones.
+
+// milestoneKind is what a milestone looks like
+type milestoneKind int
+
+const (
+	milestoneSignpost milestoneKind = iota // A commit
+	milestoneFlag                          // A push
+	milestoneMonument                      // A commit pushed in one go
+)
+
+const (
+	milestoneAhead    = 70 // Pixels in front of Claude a milestone goes up
+	milestoneGround   = 170
+	milestoneRiseTime = 0.5
+	milestoneMaxLabel = 22 // Characters of the commit message on the sign
+)
+
+// milestone is a landmark planted in the world this session
+type milestone struct {
+	kind  milestoneKind
+	label string
+	at    float64 // Distance walked when it was planted
+	rise  float32 // Seconds since it started going up
+}
+
+// plantMilestone puts up a milestone for a commit or a push
+func (r *Renderer) plantMilestone(event Event) {
+	kind := milestoneSignpost
+	switch {
+	case event.Type == EventGitPush && event.Commit != "":
+		kind = milestoneMonument
+	case event.Type == EventGitPush:
+		kind = milestoneFlag
+	case event.Commit == "":
+		return
+	}
+
+	// Only scrubbed text goes on the sign
+	label := event.Commit
+	if r.privacy != nil {
+		label = r.privacy.Event(event).Commit
+	}
+	r.milestones = append(r.milestones, milestone{
+		kind:  kind,
+		label: truncate(label, milestoneMaxLabel),
+		at:    r.walked,
+	})
+}
+
+// drawMilestones draws the milestones on screen, standing on the ground
+func (r *Renderer) drawMilestones() {
+	dt := renderFrameTime()
+	for i := range r.milestones {
+		m := &r.milestones[i]
+		x := int32(float64(screenWidth/2+milestoneAhead) - (r.walked - m.at))
+		if x < -60 || x > screenWidth+60 {
+			continue
+		}
+		if m.rise < milestoneRiseTime {
+			m.rise += dt
+		}
+		// Grows out of the ground
+		rise := m.rise / milestoneRiseTime
+		if rise > 1 {
+			rise = 1
+		}
+		y := milestoneGround + int32((1-rise*(2-rise))*30)
+
+		switch m.kind {
+		case milestoneSignpost:
+			r.drawSignpost(x, y, m.label)
+		case milestoneFlag:
+			r.drawMilestoneFlag(x, y)
+		case milestoneMonument:
+			r.drawMonument(x, y, m.label)
+		}
+	}
+}
+
+// drawMilestoneBoard draws a board with a label on it, centered on x
+func (r *Renderer) drawMilestoneBoard(x, y int32, label string, face, edge, ink rl.Color) {
+	w := r.canvas.MeasureText(label, 6) + 6
+	left := x - w/2
+	r.canvas.DrawRectangle(left, y, w, 10, edge)
+	r.canvas.DrawRectangle(left+1, y+1, w-2, 8, face)
+	r.canvas.DrawText(label, left+3, y+2, 6, ink)
+}
+
+// drawSignpost draws a wooden post with the commit message on its board
+func (r *Renderer) drawSignpost(x, y int32, label string) {
+	wood := rl.Color{R: 120, G: 80, B: 45, A: 255}
+	r.canvas.DrawRectangle(x-1, y-20, 3, 20, wood)
+	r.drawMilestoneBoard(x, y-28, label,
+		rl.Color{R: 190, G: 150, B: 95, A: 255},
+		rl.Color{R: 90, G: 60, B: 35, A: 255},
+		rl.Color{R: 50, G: 30, B: 20, A: 255})
+}
+
+// drawMilestoneFlag draws a pennant on a pole, waving
+func (r *Renderer) drawMilestoneFlag(x, y int32) {
+	r.canvas.DrawRectangle(x, y-34, 1, 34, rl.Color{R: 200, G: 195, B: 180, A: 255})
+	r.canvas.DrawCircle(x, y-35, 1.5, rl.Color{R: 255, G: 215, B: 80, A: 255})
+	wave := simpleSinF(renderTime() * 5)
+	cloth := rl.Color{R: 90, G: 200, B: 110, A: 255}
+	for i := int32(0); i < 12; i++ {
+		h := 8 - i/2
+		dy := int32(wave * float64(i) / 6)
+		r.canvas.DrawRectangle(x+1+i, y-33+dy+(8-h)/2, 1, h, cloth)
+	}
+}
+
+// drawMonument draws a stone obelisk with the commit message carved in
+func (r *Renderer) drawMonument(x, y int32, label string) {
+	stone := rl.Color{R: 150, G: 150, B: 165, A: 255}
+	shadow := rl.Color{R: 100, G: 100, B: 120, A: 255}
+	r.canvas.DrawRectangle(x-7, y-4, 14, 4, shadow)
+	r.canvas.DrawRectangle(x-4, y-30, 8, 26, stone)
+	r.canvas.DrawRectangle(x+2, y-30, 2, 26, shadow)
+	r.canvas.DrawRectangle(x-3, y-33, 6, 3, stone)
+	r.canvas.DrawRectangle(x-1, y-35, 2, 2, stone)
+	r.canvas.DrawPixel(x, y-37, rl.Color{R: 255, G: 215, B: 80, A: 255})
+	r.drawMilestoneBoard(x, y-50, label,
+		rl.Color{R: 200, G: 200, B: 210, A: 255},
+		shadow,
+		rl.Color{R: 50, G: 50, B: 70, A: 255})
+}
@@ -1,10 +1,6 @@
 package main
 
-import (
-	"math/rand"
-
-	rl "github.com/gen2brain/raylib-go/raylib"
-)
+import rl "github.com/gen2brain/raylib-go/raylib"
 
 func (r *Renderer) spawnParticles(state *AnimationState) {
 	cx := float32(screenWidth / 2)
@@ -13,12 +9,12 @@ func (r *Renderer) spawnParticles(state *AnimationState) {
 	switch state.CurrentAnim {
 	case AnimCasting:
 		// Magic sparkles
-		if rand.Float32() < 0.3 {
+		if r.rng.Float32() < 0.3 {
 			r.particles = append(r.particles, Particle{
-				X:       cx + (rand.Float32()-0.5)*40,
-				Y:       cy - 20 + (rand.Float32()-0.5)*20,
-				VX:      (rand.Float32() - 0.5) * 20,
-				VY:      -rand.Float32() * 30,
+				X:       cx + (r.rng.Float32()-0.5)*40,
+				Y:       cy - 20 + (r.rng.Float32()-0.5)*20,
+				VX:      (r.rng.Float32() - 0.5) * 20,
+				VY:      -r.rng.Float32() * 30,
 				Life:    1.0,
 				MaxLife: 1.0,
 				Color:   rl.Color{R: 255, G: 220, B: 120, A: 255},
@@ -28,12 +24,12 @@ func (r *Renderer) spawnParticles(state *AnimationState) {
 
 	case AnimAttack:
 		// Impact particles
-		if state.Frame >= 4 && state.Frame <= 6 && rand.Float32() < 0.5 {
+		if state.Frame >= 4 && state.Frame <= 6 && r.rng.Float32() < 0.5 {
 			r.particles = append(r.particles, Particle{
 				X:       cx + 20,
 				Y:       cy,
-				VX:      rand.Float32() * 40,
-				VY:      (rand.Float32() - 0.5) * 30,
+				VX:      r.rng.Float32() * 40,
+				VY:      (r.rng.Float32() - 0.5) * 30,
 				Life:    0.5,
 				MaxLife: 0.5,
 				Color:   rl.Color{R: 255, G: 255, B: 200, A: 255},
@@ -42,28 +38,42 @@ func (r *Renderer) spawnParticles(state *AnimationState) {
 		}
 
 	case AnimWriting:
-		// Ink dots
-		if rand.Float32() < 0.1 {
+		// Ink dots, flying thicker and faster for bigger edits
+		ink := state.Intensity
+		if r.rng.Float32() < 0.1+0.5*ink {
 			r.particles = append(r.particles, Particle{
 				X:       cx + 15,
 				Y:       cy + 10,
-				VX:      rand.Float32() * 5,
-				VY:      rand.Float32() * 10,
+				VX:      r.rng.Float32() * (5 + 40*ink),
+				VY:      r.rng.Float32()*10 - 30*ink,
 				Life:    0.8,
 				MaxLife: 0.8,
 				Color:   rl.Color{R: 30, G: 30, B: 50, A: 255},
-				Size:    1,
+				Size:    1 + ink,
+			})
+		}
+		// Big edits throw sparks off the quill
+		if ink > 0.5 && r.rng.Float32() < ink-0.4 {
+			r.particles = append(r.particles, Particle{
+				X:       cx + 16,
+				Y:       cy + 6,
+				VX:      10 + r.rng.Float32()*40,
+				VY:      -20 - r.rng.Float32()*40,
+				Life:    0.5,
+				MaxLife: 0.5,
+				Color:   rl.Color{R: 255, G: 220, B: 120, A: 255},
+				Size:    2,
 			})
 		}
 
 	case AnimVictory:
 		// Celebration sparkles
-		if rand.Float32() < 0.4 {
+		if r.rng.Float32() < 0.4 {
 			r.particles = append(r.particles, Particle{
-				X:       cx + (rand.Float32()-0.5)*60,
+				X:       cx + (r.rng.Float32()-0.5)*60,
 				Y:       cy - 30,
-				VX:      (rand.Float32() - 0.5) * 20,
-				VY:      -rand.Float32() * 40,
+				VX:      (r.rng.Float32() - 0.5) * 20,
+				VY:      -r.rng.Float32() * 40,
 				Life:    1.2,
 				MaxLife: 1.2,
 				Color:   rl.Color{R: 255, G: 255, B: 100, A: 255},
@@ -73,12 +83,12 @@ func (r *Renderer) spawnParticles(state *AnimationState) {
 
 	case AnimHurt:
 		// Impact stars
-		if state.Frame < 3 && rand.Float32() < 0.4 {
+		if state.Frame < 3 && r.rng.Float32() < 0.4 {
 			r.particles = append(r.particles, Particle{
 				X:       cx + 10,
 				Y:       cy - 10,
-				VX:      rand.Float32() * 30,
-				VY:      (rand.Float32() - 0.5) * 20,
+				VX:      r.rng.Float32() * 30,
+				VY:      (r.rng.Float32() - 0.5) * 20,
 				Life:    0.4,
 				MaxLife: 0.4,
 				Color:   rl.Color{R: 255, G: 100, B: 100, A: 255},
@@ -88,7 +98,7 @@ func (r *Renderer) spawnParticles(state *AnimationState) {
 
 	case AnimThinking:
 		// Thought bubbles
-		if state.Frame == 3 && rand.Float32() < 0.2 {
+		if state.Frame == 3 && r.rng.Float32() < 0.2 {
 			r.particles = append(r.particles, Particle{
 				X:       cx + 20,
 				Y:       cy - 30,
@@ -104,7 +114,7 @@ func (r *Renderer) spawnParticles(state *AnimationState) {
 }
 
 func (r *Renderer) updateParticles() {
-	dt := rl.GetFrameTime()
+	dt := renderFrameTime()
 	alive := r.particles[:0]
 
 	for i := range r.particles {
@@ -128,9 +138,9 @@ func (r *Renderer) drawParticles() {
 
 		size := int32(p.Size)
 		if p.Size > 2 {
-			rl.DrawCircle(int32(p.X), int32(p.Y), p.Size, color)
+			r.canvas.DrawCircle(int32(p.X), int32(p.Y), p.Size, color)
 		} else {
-			rl.DrawRectangle(int32(p.X), int32(p.Y), size, size, color)
+			r.canvas.DrawRectangle(int32(p.X), int32(p.Y), size, size, color)
 		}
 	}
 }
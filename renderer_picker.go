@@ -28,7 +28,7 @@ func (r *Renderer) loadHats() {
 		}
 		name := filename[:len(filename)-4]
 		path := fmt.Sprintf("%s/%s", hatsDir, filename)
-		tex := rl.LoadTexture(path)
+		tex := r.canvas.LoadTexture(path)
 		r.hats = append(r.hats, tex)
 		r.hatNames = append(r.hatNames, name)
 		fmt.Printf("Loaded hat: %s\n", name)
@@ -91,7 +91,7 @@ func (r *Renderer) loadFaces() {
 		}
 		name := filename[:len(filename)-4]
 		path := fmt.Sprintf("%s/%s", facesDir, filename)
-		tex := rl.LoadTexture(path)
+		tex := r.canvas.LoadTexture(path)
 		r.faces = append(r.faces, tex)
 		r.faceNames = append(r.faceNames, name)
 		fmt.Printf("Loaded face: %s\n", name)
@@ -243,17 +243,14 @@ func (r *Renderer) LoadPrefs() {
 // UpdateScroll advances the parallax scroll (call each frame)
 func (r *Renderer) UpdateScroll(dt float32) {
 	r.scrollOffset += dt * 25 // Walk speed
+	r.walked += float64(dt * 25)
 	// Wrap around to prevent overflow
 	if r.scrollOffset > 10000 {
 		r.scrollOffset -= 10000
 	}
 
-	// Biome transitions every ~20 seconds of walking
-	r.biomeTimer += dt
-	if r.biomeTimer > 20 {
-		r.biomeTimer = 0
-		r.currentBiome = (r.currentBiome + 1) % 5
-	}
+	// Biome transitions after the dwell time of walking
+	r.updateBiome(dt)
 }
 
 // UpdateScrollOnly advances scroll without biome cycling (for studio mode)
@@ -287,12 +284,12 @@ func (r *Renderer) DrawAccessoryPickerHint() {
 	panelBg := rl.Color{R: 20, G: 18, B: 30, A: 200}
 	panelBorder := rl.Color{R: 60, G: 55, B: 80, A: 200}
 
-	rl.DrawRectangle(panelX-1, panelY-1, panelW+2, panelH+2, panelBorder)
-	rl.DrawRectangle(panelX, panelY, panelW, panelH, panelBg)
+	r.canvas.DrawRectangle(panelX-1, panelY-1, panelW+2, panelH+2, panelBorder)
+	r.canvas.DrawRectangle(panelX, panelY, panelW, panelH, panelBg)
 
 	// Hint text
 	hintColor := rl.Color{R: 120, G: 115, B: 150, A: 255}
-	rl.DrawText("Tab", panelX+4, panelY+1, 8, hintColor)
+	r.canvas.DrawText("Tab", panelX+4, panelY+1, 8, hintColor)
 
 	// Show current equipped count
 	ownedCount := 0
@@ -312,6 +309,6 @@ func (r *Renderer) DrawAccessoryPickerHint() {
 	if ownedCount > 0 {
 		countColor := rl.Color{R: 255, G: 200, B: 80, A: 255}
 		countText := fmt.Sprintf("%d", ownedCount)
-		rl.DrawText(countText, panelX+30, panelY+1, 8, countColor)
+		r.canvas.DrawText(countText, panelX+30, panelY+1, 8, countColor)
 	}
 }
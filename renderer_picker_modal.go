@@ -30,6 +30,7 @@ var auraColors = map[string]rl.Color{
 	"aura_heart":    {R: 255, G: 100, B: 150, A: 255},
 	"aura_code":     {R: 0, G: 255, B: 100, A: 255},
 	"aura_rainbow":  {R: 255, G: 100, B: 100, A: 255},
+	"aura_snake":    {R: 255, G: 212, B: 59, A: 255},
 }
 
 var trailColors = map[string]rl.Color{
@@ -39,6 +40,7 @@ var trailColors = map[string]rl.Color{
 	"trail_hearts":  {R: 255, G: 100, B: 150, A: 255},
 	"trail_pixel":   {R: 100, G: 255, B: 100, A: 255},
 	"trail_rainbow": {R: 255, G: 200, B: 100, A: 255},
+	"trail_gopher":  {R: 0, G: 173, B: 216, A: 255},
 }
 
 // ToggleModalPicker opens or closes the picker strip
@@ -264,8 +266,8 @@ func (r *Renderer) DrawModalPicker() {
 	bgColor := rl.Color{R: 20, G: 18, B: 35, A: uint8(eased * 220)}
 	borderColor := rl.Color{R: 60, G: 55, B: 85, A: uint8(eased * 255)}
 
-	rl.DrawRectangle(stripX-1, int32(baseY)-1, stripW+2, stripHeight+2, borderColor)
-	rl.DrawRectangle(stripX, int32(baseY), stripW, stripHeight, bgColor)
+	r.canvas.DrawRectangle(stripX-1, int32(baseY)-1, stripW+2, stripHeight+2, borderColor)
+	r.canvas.DrawRectangle(stripX, int32(baseY), stripW, stripHeight, bgColor)
 
 	// Draw four slots centered
 	slotNames := []string{"HAT", "FACE", "AURA", "TRAIL"}
@@ -288,9 +290,9 @@ func (r *Renderer) drawPickerSlot(x, y int32, slot int, label string, alpha floa
 
 	if isActive {
 		highlightColor := rl.Color{R: 255, G: 200, B: 80, A: uint8(alpha * 50)}
-		rl.DrawRectangle(cellX, cellY, cellW, cellH, highlightColor)
+		r.canvas.DrawRectangle(cellX, cellY, cellW, cellH, highlightColor)
 		borderColor := rl.Color{R: 255, G: 200, B: 80, A: a}
-		rl.DrawRectangleLines(cellX, cellY, cellW, cellH, borderColor)
+		r.canvas.DrawRectangleLines(cellX, cellY, cellW, cellH, borderColor)
 	}
 
 	// Vertical layout in 28px cell:
@@ -304,10 +306,10 @@ func (r *Renderer) drawPickerSlot(x, y int32, slot int, label string, alpha floa
 	if isActive {
 		labelColor = rl.Color{R: 180, G: 170, B: 200, A: a}
 	}
-	labelW := rl.MeasureText(label, 6)
+	labelW := r.canvas.MeasureText(label, 6)
 	labelX := cellX + (cellW-labelW)/2
 	labelY := cellY + 4
-	rl.DrawText(label, labelX, labelY, 6, labelColor)
+	r.canvas.DrawText(label, labelX, labelY, 6, labelColor)
 
 	// Get item info
 	itemInfo := r.getSlotItemInfo(slot)
@@ -322,7 +324,7 @@ func (r *Renderer) drawPickerSlot(x, y int32, slot int, label string, alpha floa
 		dashX := cellX + (cellW-dashW)/2
 		dashY := iconAreaY + (slotIconSize-dashH)/2
 		dashColor := rl.Color{R: 70, G: 65, B: 90, A: a}
-		rl.DrawRectangle(dashX, dashY, dashW, dashH, dashColor)
+		r.canvas.DrawRectangle(dashX, dashY, dashW, dashH, dashColor)
 	} else if !itemInfo.owned {
 		// Locked - lock icon + "Lv##" centered together
 		// Lock: 6x8, text: ~20px for "Lv##", gap: 2px
@@ -330,7 +332,10 @@ func (r *Renderer) drawPickerSlot(x, y int32, slot int, label string, alpha floa
 		lockW := int32(6)
 		lockH := int32(8)
 		lvlText := fmt.Sprintf("Lv%d", itemInfo.level)
-		textW := rl.MeasureText(lvlText, 6)
+		if itemInfo.language != "" {
+			lvlText = itemInfo.language
+		}
+		textW := r.canvas.MeasureText(lvlText, 6)
 		gap := int32(3)
 		totalW := lockW + gap + textW
 
@@ -340,14 +345,14 @@ func (r *Renderer) drawPickerSlot(x, y int32, slot int, label string, alpha floa
 		// Draw lock
 		lockColor := rl.Color{R: 90, G: 75, B: 65, A: a}
 		// Lock shackle (top part)
-		rl.DrawRectangle(startX+1, lockY, 4, 3, lockColor)
+		r.canvas.DrawRectangle(startX+1, lockY, 4, 3, lockColor)
 		// Lock body
-		rl.DrawRectangle(startX, lockY+2, lockW, 5, lockColor)
+		r.canvas.DrawRectangle(startX, lockY+2, lockW, 5, lockColor)
 
 		// Draw level text
 		lvlColor := rl.Color{R: 150, G: 120, B: 70, A: a}
 		textY := iconAreaY + (slotIconSize-6)/2
-		rl.DrawText(lvlText, startX+lockW+gap, textY, 6, lvlColor)
+		r.canvas.DrawText(lvlText, startX+lockW+gap, textY, 6, lvlColor)
 	} else {
 		// Owned item - draw icon centered
 		iconX := cellX + (cellW-slotIconSize)/2
@@ -358,9 +363,10 @@ func (r *Renderer) drawPickerSlot(x, y int32, slot int, label string, alpha floa
 
 // slotItemInfo holds info about the currently displayed item in a slot
 type slotItemInfo struct {
-	id    string
-	owned bool
-	level int
+	id       string
+	owned    bool
+	level    int
+	language string // Drops unlock by language, not level
 }
 
 // getSlotItemInfo gets info about what's currently shown in a slot
@@ -405,7 +411,7 @@ func (r *Renderer) getSlotItemInfo(slot int) slotItemInfo {
 		if item.Slot == slotType {
 			isOwned := r.profile != nil && r.profile.IsOwned(item.ID)
 			if !isOwned {
-				items = append(items, slotItemInfo{id: item.ID, owned: false, level: item.MinLevel})
+				items = append(items, slotItemInfo{id: item.ID, owned: false, level: item.MinLevel, language: item.Language})
 			}
 		}
 	}
@@ -426,7 +432,7 @@ func (r *Renderer) drawSlotItemIcon(x, y int32, slot int, itemID string, alpha u
 				hat := r.hats[i]
 				srcRec := rl.Rectangle{X: 0, Y: 0, Width: float32(hat.Width), Height: float32(hat.Height)}
 				dstRec := rl.Rectangle{X: float32(x), Y: float32(y), Width: slotIconSize, Height: slotIconSize}
-				rl.DrawTexturePro(hat, srcRec, dstRec, rl.Vector2{}, 0, rl.Color{R: 255, G: 255, B: 255, A: alpha})
+				r.canvas.DrawTexturePro(hat, srcRec, dstRec, rl.Vector2{}, 0, rl.Color{R: 255, G: 255, B: 255, A: alpha})
 				return
 			}
 		}
@@ -436,7 +442,7 @@ func (r *Renderer) drawSlotItemIcon(x, y int32, slot int, itemID string, alpha u
 				face := r.faces[i]
 				srcRec := rl.Rectangle{X: 0, Y: 0, Width: float32(face.Width), Height: float32(face.Height)}
 				dstRec := rl.Rectangle{X: float32(x), Y: float32(y), Width: slotIconSize, Height: slotIconSize}
-				rl.DrawTexturePro(face, srcRec, dstRec, rl.Vector2{}, 0, rl.Color{R: 255, G: 255, B: 255, A: alpha})
+				r.canvas.DrawTexturePro(face, srcRec, dstRec, rl.Vector2{}, 0, rl.Color{R: 255, G: 255, B: 255, A: alpha})
 				return
 			}
 		}
@@ -445,13 +451,13 @@ func (r *Renderer) drawSlotItemIcon(x, y int32, slot int, itemID string, alpha u
 			// Outer glow
 			glowColor := color
 			glowColor.A = alpha / 3
-			rl.DrawRectangle(x-1, y-1, slotIconSize+2, slotIconSize+2, glowColor)
+			r.canvas.DrawRectangle(x-1, y-1, slotIconSize+2, slotIconSize+2, glowColor)
 			// Main swatch
 			color.A = alpha
-			rl.DrawRectangle(x, y, slotIconSize, slotIconSize, color)
+			r.canvas.DrawRectangle(x, y, slotIconSize, slotIconSize, color)
 			// Inner highlight
 			innerColor := rl.Color{R: 255, G: 255, B: 255, A: alpha / 3}
-			rl.DrawRectangle(x+2, y+2, slotIconSize-4, slotIconSize-4, innerColor)
+			r.canvas.DrawRectangle(x+2, y+2, slotIconSize-4, slotIconSize-4, innerColor)
 		}
 	case 3: // TRAIL - dust particles flowing left (opposite to Claude's walk direction)
 		if color, ok := trailColors[itemID]; ok {
@@ -459,15 +465,15 @@ func (r *Renderer) drawSlotItemIcon(x, y int32, slot int, itemID string, alpha u
 			// Particles flow from right to left (Claude walks right, dust trails behind)
 			// Rightmost = newest/brightest, leftmost = oldest/faintest
 			color.A = alpha / 4
-			rl.DrawCircle(x+1, cy-1, 1, color) // Faint, dispersed
+			r.canvas.DrawCircle(x+1, cy-1, 1, color) // Faint, dispersed
 			color.A = alpha / 3
-			rl.DrawCircle(x+3, cy+1, 1, color) // Fading
+			r.canvas.DrawCircle(x+3, cy+1, 1, color) // Fading
 			color.A = alpha / 2
-			rl.DrawCircle(x+5, cy-1, 1, color) // Mid
+			r.canvas.DrawCircle(x+5, cy-1, 1, color) // Mid
 			color.A = alpha * 2 / 3
-			rl.DrawCircle(x+7, cy, 2, color) // Brighter
+			r.canvas.DrawCircle(x+7, cy, 2, color) // Brighter
 			color.A = alpha
-			rl.DrawCircle(x+10, cy, 2, color) // Brightest (just left Claude)
+			r.canvas.DrawCircle(x+10, cy, 2, color) // Brightest (just left Claude)
 		}
 	}
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -12,12 +13,21 @@ func (r *Renderer) DrawGameUI(state *GameState) {
 	// Draw level display first (so other UI can render on top)
 	r.drawLevelDisplay(state)
 
+	// Draw gold counter (estimated cost, top right)
+	r.drawGoldCounter(state)
+
+	// Draw cache rune and hit-ratio sparkline (below gold counter)
+	r.drawCacheRune(state)
+
 	// Draw mana bar at bottom
 	r.drawManaBar(state)
 
 	// Draw XP bar (above mana bar)
 	r.drawXPBar(state)
 
+	// Draw stamina bar (rolling usage window, bottom left)
+	r.drawStaminaBar(state)
+
 	// Draw flow meter (right side)
 	r.drawFlowMeter(state)
 
@@ -31,6 +41,7 @@ func (r *Renderer) DrawGameUI(state *GameState) {
 
 	// Draw floating XP indicators (above thought bubble)
 	r.drawFloatingXPs(state)
+	r.drawFloatingDiffs(state)
 
 	// Draw SHIPPED! rainbow banner (git push celebration)
 	if state.ShippedActive {
@@ -55,6 +66,11 @@ func (r *Renderer) DrawGameUI(state *GameState) {
 	if state.CompactActive {
 		r.drawCompactEffect(state)
 	}
+
+	// Draw resting-at-the-inn overlay while rate limited
+	if state.Resting {
+		r.drawRestingEffect(state)
+	}
 }
 
 // drawThrownTools renders tool names flying through the air
@@ -80,16 +96,16 @@ func (r *Renderer) drawThrownTools(state *GameState) {
 		fontSize := int32(8)
 
 		// Shadow
-		rl.DrawText(tool.Text, x+1, y+1, fontSize, shadowColor)
+		r.canvas.DrawText(tool.Text, x+1, y+1, fontSize, shadowColor)
 		// Main text
-		rl.DrawText(tool.Text, x, y, fontSize, color)
+		r.canvas.DrawText(tool.Text, x, y, fontSize, color)
 
 		// Small trail particles
 		if tool.Life < tool.MaxLife*0.5 {
 			trailColor := color
 			trailColor.A = uint8(alpha * 100)
-			rl.DrawRectangle(x-3, y+3, 2, 2, trailColor)
-			rl.DrawRectangle(x-6, y+4, 2, 2, trailColor)
+			r.canvas.DrawRectangle(x-3, y+3, 2, 2, trailColor)
+			r.canvas.DrawRectangle(x-6, y+4, 2, 2, trailColor)
 		}
 	}
 }
@@ -105,6 +121,18 @@ func (r *Renderer) drawFlyingEnemies(state *GameState) {
 
 		alpha := uint8(255)
 
+		// A language's nemesis takes on its color and wears its name
+		tint := rl.Color{R: 255, G: 255, B: 255, A: alpha}
+		if lang := enemy.Language; lang != nil {
+			c := lang.Color
+			color := rl.Color{R: uint8(c >> 24), G: uint8(c >> 16), B: uint8(c >> 8), A: alpha}
+			tint = lerpColor(tint, color, 0.5)
+			w := r.canvas.MeasureText(lang.Nemesis, 6)
+			x, y := int32(enemy.X)-w/2, int32(enemy.Y)-enemyFrameHeight/2-8
+			r.canvas.DrawText(lang.Nemesis, x+1, y+1, 6, rl.Color{R: 0, G: 0, B: 0, A: 180})
+			r.canvas.DrawText(lang.Nemesis, x, y, 6, color)
+		}
+
 		if r.hasEnemySprites {
 			// Calculate source rectangle from enemy sprite sheet
 			frameX := float32(enemy.Frame * enemyFrameWidth)
@@ -125,8 +153,7 @@ func (r *Renderer) drawFlyingEnemies(state *GameState) {
 				Height: enemyFrameHeight,
 			}
 
-			tint := rl.Color{R: 255, G: 255, B: 255, A: alpha}
-			rl.DrawTexturePro(r.enemySpriteSheet, sourceRec, destRec, rl.Vector2{}, 0, tint)
+			r.canvas.DrawTexturePro(r.enemySpriteSheet, sourceRec, destRec, rl.Vector2{}, 0, tint)
 		} else {
 			// Fallback: draw colored rectangle
 			var color rl.Color
@@ -137,8 +164,10 @@ func (r *Renderer) drawFlyingEnemies(state *GameState) {
 				color = rl.Color{R: 255, G: 51, B: 51, A: alpha} // Red
 			case EnemyLowContext:
 				color = rl.Color{R: 255, G: 204, B: 0, A: alpha} // Yellow
+			case EnemyOverBudget:
+				color = rl.Color{R: 255, G: 180, B: 40, A: alpha} // Gold
 			}
-			rl.DrawRectangle(int32(enemy.X)-16, int32(enemy.Y)-8, 32, 16, color)
+			r.canvas.DrawRectangle(int32(enemy.X)-16, int32(enemy.Y)-8, 32, 16, color)
 		}
 	}
 }
@@ -158,6 +187,8 @@ func (r *Renderer) drawImpactEffect(x, y, timer float32, enemyType EnemyType) {
 		color = rl.Color{R: 255, G: 100, B: 100, A: uint8(200 * (1 - progress))} // Red
 	case EnemyLowContext:
 		color = rl.Color{R: 255, G: 220, B: 100, A: uint8(200 * (1 - progress))} // Yellow
+	case EnemyOverBudget:
+		color = rl.Color{R: 255, G: 190, B: 60, A: uint8(200 * (1 - progress))} // Gold
 	}
 
 	// Draw expanding ring
@@ -166,20 +197,20 @@ func (r *Renderer) drawImpactEffect(x, y, timer float32, enemyType EnemyType) {
 	halfSize := int32(size / 2)
 
 	// Outer ring
-	rl.DrawRectangleLines(cx-halfSize, cy-halfSize, int32(size), int32(size), color)
+	r.canvas.DrawRectangleLines(cx-halfSize, cy-halfSize, int32(size), int32(size), color)
 
 	// Inner burst lines (star pattern)
 	for i := 0; i < 8; i++ {
 		angle := float32(i) * 3.14159 / 4
 		dx := int32(simpleCosF(float64(angle)) * float64(size/2))
 		dy := int32(simpleSinF(float64(angle)) * float64(size/2))
-		rl.DrawLine(cx, cy, cx+dx, cy+dy, color)
+		r.canvas.DrawLine(cx, cy, cx+dx, cy+dy, color)
 	}
 
 	// Center flash
 	flashAlpha := uint8(255 * (1 - progress))
 	flashColor := rl.Color{R: 255, G: 255, B: 255, A: flashAlpha}
-	rl.DrawRectangle(cx-3, cy-3, 6, 6, flashColor)
+	r.canvas.DrawRectangle(cx-3, cy-3, 6, 6, flashColor)
 }
 
 // drawMiniAgents renders all active mini Claudes (subagents)
@@ -192,13 +223,13 @@ func (r *Renderer) drawMiniAgents(state *GameState) {
 
 			// Simple colored box
 			bodyColor := rl.Color{R: 218, G: 165, B: 140, A: 255} // Peach
-			rl.DrawRectangle(x, y, 16, 16, bodyColor)
+			r.canvas.DrawRectangle(x, y, 16, 16, bodyColor)
 
 			// Draw name below
 			nameColor := rl.Color{R: 200, G: 180, B: 160, A: 255}
-			textWidth := rl.MeasureText(agent.Name, 6)
+			textWidth := r.canvas.MeasureText(agent.Name, 6)
 			nameX := x + 8 - textWidth/2
-			rl.DrawText(agent.Name, nameX, y+18, 6, nameColor)
+			r.canvas.DrawText(agent.Name, nameX, y+18, 6, nameColor)
 		}
 		return
 	}
@@ -224,19 +255,19 @@ func (r *Renderer) drawMiniAgents(state *GameState) {
 			Height: miniFrameHeight,
 		}
 
-		rl.DrawTexturePro(r.miniSpriteSheet, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
+		r.canvas.DrawTexturePro(r.miniSpriteSheet, sourceRec, destRec, rl.Vector2{}, 0, rl.White)
 
 		// Draw agent name below the sprite
 		nameColor := rl.Color{R: 200, G: 180, B: 160, A: 255}
 		shadowColor := rl.Color{R: 0, G: 0, B: 0, A: 150}
-		textWidth := rl.MeasureText(agent.Name, 6)
+		textWidth := r.canvas.MeasureText(agent.Name, 6)
 		nameX := int32(agent.X) - textWidth/2
 		nameY := int32(agent.Y) + 2
 
 		// Shadow
-		rl.DrawText(agent.Name, nameX+1, nameY+1, 6, shadowColor)
+		r.canvas.DrawText(agent.Name, nameX+1, nameY+1, 6, shadowColor)
 		// Name text
-		rl.DrawText(agent.Name, nameX, nameY, 6, nameColor)
+		r.canvas.DrawText(agent.Name, nameX, nameY, 6, nameColor)
 	}
 }
 
@@ -261,7 +292,7 @@ func (r *Renderer) drawQuestText(state *GameState) {
 
 	// Word wrap the text
 	maxLineWidth := panelWidth - padding*2 - 2
-	lines := wordWrap(state.QuestText, 6, maxLineWidth)
+	lines := r.wordWrap(state.QuestText, 6, maxLineWidth)
 	if len(lines) > 3 {
 		lines = lines[:3] // Max 3 lines
 		lines[2] = lines[2] + "..."
@@ -271,18 +302,18 @@ func (r *Renderer) drawQuestText(state *GameState) {
 	panelHeight := int32(len(lines))*lineHeight + padding*2
 
 	// Draw panel background
-	rl.DrawRectangle(panelX-1, panelY-1, panelWidth+2, panelHeight+2, borderColor)
-	rl.DrawRectangle(panelX, panelY, panelWidth, panelHeight, panelBg)
+	r.canvas.DrawRectangle(panelX-1, panelY-1, panelWidth+2, panelHeight+2, borderColor)
+	r.canvas.DrawRectangle(panelX, panelY, panelWidth, panelHeight, panelBg)
 
 	// Draw each line
 	for i, line := range lines {
 		y := panelY + padding + int32(i)*lineHeight
 		if i == 0 {
 			// First line with label
-			rl.DrawText(">", panelX+padding, y, 6, labelColor)
-			rl.DrawText(line, panelX+padding+8, y, 6, textColor)
+			r.canvas.DrawText(">", panelX+padding, y, 6, labelColor)
+			r.canvas.DrawText(line, panelX+padding+8, y, 6, textColor)
 		} else {
-			rl.DrawText(line, panelX+padding+8, y, 6, textColor)
+			r.canvas.DrawText(line, panelX+padding+8, y, 6, textColor)
 		}
 	}
 }
@@ -317,7 +348,7 @@ func (r *Renderer) drawThoughtBubble(state *GameState) {
 	maxBubbleWidth := int32(180)
 
 	// Word wrap the text
-	lines := wordWrap(thoughtText, fontSize, maxBubbleWidth-padding*2)
+	lines := r.wordWrap(thoughtText, fontSize, maxBubbleWidth-padding*2)
 	if len(lines) > 4 {
 		lines = lines[:4]
 		lines[3] = lines[3][:min(len(lines[3]), 20)] + "..."
@@ -329,7 +360,7 @@ func (r *Renderer) drawThoughtBubble(state *GameState) {
 	// Calculate text width for bubble sizing
 	maxTextWidth := int32(0)
 	for _, line := range lines {
-		w := int32(rl.MeasureText(line, fontSize))
+		w := int32(r.canvas.MeasureText(line, fontSize))
 		if w > maxTextWidth {
 			maxTextWidth = w
 		}
@@ -356,17 +387,17 @@ func (r *Renderer) drawThoughtBubble(state *GameState) {
 	}
 
 	// Draw shadow
-	rl.DrawRectangleRounded(
+	r.canvas.DrawRectangleRounded(
 		rl.Rectangle{X: float32(bubbleX + 2), Y: float32(bubbleY + 2), Width: float32(bubbleWidth), Height: float32(bubbleHeight)},
 		0.3, 8, shadowColor,
 	)
 
 	// Draw main bubble with rounded corners
-	rl.DrawRectangleRounded(
+	r.canvas.DrawRectangleRounded(
 		rl.Rectangle{X: float32(bubbleX), Y: float32(bubbleY), Width: float32(bubbleWidth), Height: float32(bubbleHeight)},
 		0.3, 8, bubbleBg,
 	)
-	rl.DrawRectangleRoundedLines(
+	r.canvas.DrawRectangleRoundedLines(
 		rl.Rectangle{X: float32(bubbleX), Y: float32(bubbleY), Width: float32(bubbleWidth), Height: float32(bubbleHeight)},
 		0.3, 8, bubbleBorder,
 	)
@@ -386,17 +417,17 @@ func (r *Renderer) drawThoughtBubble(state *GameState) {
 	dy := float32(claudeHeadY - tailStartY)
 
 	// Draw circles along the path, getting smaller as they approach Claude
-	rl.DrawCircle(tailStartX+int32(dx*0.2), tailStartY+int32(dy*0.25), 4, bubbleBg)
-	rl.DrawCircleLines(tailStartX+int32(dx*0.2), tailStartY+int32(dy*0.25), 4, bubbleBorder)
-	rl.DrawCircle(tailStartX+int32(dx*0.45), tailStartY+int32(dy*0.5), 3, bubbleBg)
-	rl.DrawCircleLines(tailStartX+int32(dx*0.45), tailStartY+int32(dy*0.5), 3, bubbleBorder)
-	rl.DrawCircle(tailStartX+int32(dx*0.7), tailStartY+int32(dy*0.75), 2, bubbleBg)
-	rl.DrawCircleLines(tailStartX+int32(dx*0.7), tailStartY+int32(dy*0.75), 2, bubbleBorder)
+	r.canvas.DrawCircle(tailStartX+int32(dx*0.2), tailStartY+int32(dy*0.25), 4, bubbleBg)
+	r.canvas.DrawCircleLines(tailStartX+int32(dx*0.2), tailStartY+int32(dy*0.25), 4, bubbleBorder)
+	r.canvas.DrawCircle(tailStartX+int32(dx*0.45), tailStartY+int32(dy*0.5), 3, bubbleBg)
+	r.canvas.DrawCircleLines(tailStartX+int32(dx*0.45), tailStartY+int32(dy*0.5), 3, bubbleBorder)
+	r.canvas.DrawCircle(tailStartX+int32(dx*0.7), tailStartY+int32(dy*0.75), 2, bubbleBg)
+	r.canvas.DrawCircleLines(tailStartX+int32(dx*0.7), tailStartY+int32(dy*0.75), 2, bubbleBorder)
 
 	// Draw text with better vertical centering
 	for i, line := range lines {
 		y := bubbleY + padding + 2 + int32(i)*lineHeight
-		rl.DrawText(line, bubbleX+padding+2, y, fontSize, textColor)
+		r.canvas.DrawText(line, bubbleX+padding+2, y, fontSize, textColor)
 	}
 }
 
@@ -411,8 +442,8 @@ func (r *Renderer) drawManaBar(state *GameState) {
 	// Background
 	bgColor := rl.Color{R: 20, G: 18, B: 30, A: 230}
 	borderColor := rl.Color{R: 60, G: 55, B: 80, A: 255}
-	rl.DrawRectangle(barX-1, barY-1, barWidth+2, barHeight+2, borderColor)
-	rl.DrawRectangle(barX, barY, barWidth, barHeight, bgColor)
+	r.canvas.DrawRectangle(barX-1, barY-1, barWidth+2, barHeight+2, borderColor)
+	r.canvas.DrawRectangle(barX, barY, barWidth, barHeight, bgColor)
 
 	// Calculate fill (mana drains as tokens are used)
 	usedRatio := state.ManaDisplay / float32(state.ManaMax)
@@ -440,12 +471,24 @@ func (r *Renderer) drawManaBar(state *GameState) {
 
 	// Draw fill
 	if fillWidth > 0 {
-		rl.DrawRectangle(barX+1, barY+1, fillWidth, barHeight-2, fillColor)
+		r.canvas.DrawRectangle(barX+1, barY+1, fillWidth, barHeight-2, fillColor)
+	}
+
+	// Auto-compact tick, labelled with the turns forecast
+	tickX := barX + 1 + int32(float32(barWidth-2)*(1-autoCompactRatio))
+	tickColor := rl.Color{R: 230, G: 225, B: 245, A: 200}
+	r.canvas.DrawRectangle(tickX, barY-1, 1, barHeight+2, tickColor)
+	if turns := state.Forecast.TurnsLeft(state.ManaTotal, state.ManaMax); turns >= 0 {
+		turnsColor := rl.Color{R: 160, G: 155, B: 180, A: 255}
+		if turns < forecastWarnTurns {
+			turnsColor = rl.Color{R: 255, G: 120, B: 100, A: 255}
+		}
+		r.canvas.DrawText(fmt.Sprintf("~%d turns", turns), tickX+3, barY+1, 8, turnsColor)
 	}
 
 	// Draw label
 	labelColor := rl.Color{R: 120, G: 115, B: 140, A: 255}
-	rl.DrawText("MANA", barX-30, barY, 8, labelColor)
+	r.canvas.DrawText("MANA", barX-30, barY, 8, labelColor)
 
 	// Draw remaining token count
 	remaining := state.ManaMax - int(state.ManaDisplay)
@@ -453,8 +496,8 @@ func (r *Renderer) drawManaBar(state *GameState) {
 		remaining = 0
 	}
 	tokenText := fmt.Sprintf("%dk", remaining/1000)
-	textWidth := rl.MeasureText(tokenText, 8)
-	rl.DrawText(tokenText, barX+barWidth-textWidth-2, barY+1, 8, rl.Color{R: 160, G: 155, B: 180, A: 255})
+	textWidth := r.canvas.MeasureText(tokenText, 8)
+	r.canvas.DrawText(tokenText, barX+barWidth-textWidth-2, barY+1, 8, rl.Color{R: 160, G: 155, B: 180, A: 255})
 }
 
 // drawXPBar renders the XP progress bar (above mana bar)
@@ -472,8 +515,8 @@ func (r *Renderer) drawXPBar(state *GameState) {
 	// Background
 	bgColor := rl.Color{R: 20, G: 18, B: 30, A: 200}
 	borderColor := rl.Color{R: 50, G: 45, B: 70, A: 255}
-	rl.DrawRectangle(barX-1, barY-1, barWidth+2, barHeight+2, borderColor)
-	rl.DrawRectangle(barX, barY, barWidth, barHeight, bgColor)
+	r.canvas.DrawRectangle(barX-1, barY-1, barWidth+2, barHeight+2, borderColor)
+	r.canvas.DrawRectangle(barX, barY, barWidth, barHeight, bgColor)
 
 	// Calculate fill based on XP progress to next level
 	progress := state.Profile.XPProgress()
@@ -484,12 +527,71 @@ func (r *Renderer) drawXPBar(state *GameState) {
 
 	// Draw fill
 	if fillWidth > 0 {
-		rl.DrawRectangle(barX+1, barY+1, fillWidth, barHeight-2, fillColor)
+		r.canvas.DrawRectangle(barX+1, barY+1, fillWidth, barHeight-2, fillColor)
 	}
 
 	// Draw XP label
 	labelColor := rl.Color{R: 120, G: 115, B: 140, A: 255}
-	rl.DrawText("XP", barX-16, barY-1, 6, labelColor)
+	r.canvas.DrawText("XP", barX-16, barY-1, 6, labelColor)
+}
+
+// drawStaminaBar renders the rolling usage window with a reset countdown
+func (r *Renderer) drawStaminaBar(state *GameState) {
+	usage := state.Usage
+
+	// Position above the accessory hint, level with the XP bar
+	barHeight := int32(6)
+	barX := int32(4)
+	barY := int32(screenHeight - 10 - 4 - barHeight - 2)
+	barWidth := int32(50)
+
+	// Background
+	bgColor := rl.Color{R: 20, G: 18, B: 30, A: 200}
+	borderColor := rl.Color{R: 50, G: 45, B: 70, A: 255}
+	r.canvas.DrawRectangle(barX-1, barY-1, barWidth+2, barHeight+2, borderColor)
+	r.canvas.DrawRectangle(barX, barY, barWidth, barHeight, bgColor)
+
+	// Green when fresh, orange when tired, red when nearly spent
+	stamina := usage.Stamina()
+	if state.Resting {
+		stamina = 0
+	}
+	fillWidth := int32(float32(barWidth-2) * stamina)
+	fillColor := rl.Color{R: 100, G: 220, B: 120, A: 255}
+	if stamina < 0.2 {
+		fillColor = rl.Color{R: 255, G: 80, B: 80, A: 255}
+	} else if stamina < 0.5 {
+		fillColor = rl.Color{R: 255, G: 170, B: 60, A: 255}
+	}
+	if fillWidth > 0 {
+		r.canvas.DrawRectangle(barX+1, barY+1, fillWidth, barHeight-2, fillColor)
+	}
+
+	// Reset countdown to the right of the bar
+	labelColor := rl.Color{R: 120, G: 115, B: 140, A: 255}
+	resetAt := usage.ResetAt
+	if state.Resting {
+		resetAt = usage.LimitedUntil
+	}
+	if !resetAt.IsZero() {
+		r.canvas.DrawText(formatCountdown(time.Until(resetAt)), barX+barWidth+4, barY-1, 6, labelColor)
+	}
+	r.canvas.DrawText("STAMINA", barX, barY-8, 6, labelColor)
+}
+
+// formatCountdown formats a duration as a short countdown (e.g. 2h13m, 7m, 40s)
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
 }
 
 // drawLevelDisplay renders the current level
@@ -509,9 +611,101 @@ func (r *Renderer) drawLevelDisplay(state *GameState) {
 	y := int32(4)
 
 	// Shadow
-	rl.DrawText(levelText, x+1, y+1, 8, shadowColor)
+	r.canvas.DrawText(levelText, x+1, y+1, 8, shadowColor)
 	// Main text
-	rl.DrawText(levelText, x, y, 8, levelColor)
+	r.canvas.DrawText(levelText, x, y, 8, levelColor)
+}
+
+// drawGoldCounter renders the estimated session cost as a coin counter
+func (r *Renderer) drawGoldCounter(state *GameState) {
+	if state.Config == nil {
+		return
+	}
+
+	goldText := formatCost(state.SessionCost)
+	textWidth := r.canvas.MeasureText(goldText, 8)
+
+	// Gold turns red once a budget is spent
+	goldColor := rl.Color{R: 255, G: 200, B: 80, A: 255}
+	if state.Budgets.OverBudget() {
+		goldColor = rl.Color{R: 255, G: 100, B: 80, A: 255}
+	}
+	shadowColor := rl.Color{R: 0, G: 0, B: 0, A: 150}
+	coinDark := rl.Color{R: 180, G: 120, B: 30, A: 255}
+
+	x := int32(screenWidth) - textWidth - 4
+	y := int32(4)
+
+	// Coin icon (6x6 with a dark rim)
+	coinX := x - 9
+	r.canvas.DrawRectangle(coinX+1, y+1, 6, 6, shadowColor)
+	r.canvas.DrawRectangle(coinX+1, y, 4, 6, coinDark)
+	r.canvas.DrawRectangle(coinX, y+1, 6, 4, coinDark)
+	r.canvas.DrawRectangle(coinX+1, y+1, 4, 4, goldColor)
+	r.canvas.DrawRectangle(coinX+2, y+2, 1, 2, rl.Color{R: 255, G: 240, B: 180, A: 255})
+
+	r.canvas.DrawText(goldText, x+1, y+1, 8, shadowColor)
+	r.canvas.DrawText(goldText, x, y, 8, goldColor)
+}
+
+// drawCacheRune renders the prompt cache rune, which glows with the cache hit
+// ratio and flickers dim while the cache is being rebuilt, plus a sparkline
+func (r *Renderer) drawCacheRune(state *GameState) {
+	cache := &state.Cache
+	if cache.Turns == 0 {
+		return
+	}
+
+	// Rune position (right edge, under the gold counter)
+	runeX := int32(screenWidth - 11)
+	runeY := int32(15)
+
+	glow := cache.Glow
+	if cache.Rebuilding() {
+		// Sputtering flicker while the cache is rewritten
+		glow *= 0.6 + 0.4*float32(simpleSinF(renderTime()*20))
+	}
+	if glow < 0.15 {
+		glow = 0.15
+	}
+
+	// Halo behind the rune
+	if glow > 0.5 {
+		haloAlpha := uint8((glow - 0.5) * 2 * 90)
+		r.canvas.DrawRectangle(runeX-2, runeY-1, 11, 11, rl.Color{R: 80, G: 220, B: 255, A: haloAlpha / 2})
+		r.canvas.DrawRectangle(runeX-1, runeY-2, 9, 13, rl.Color{R: 80, G: 220, B: 255, A: haloAlpha / 2})
+	}
+
+	// Diamond rune stone with a glyph carved in
+	stone := rl.Color{R: 40, G: 40, B: 60, A: 230}
+	r.canvas.DrawRectangle(runeX+3, runeY, 1, 9, stone)
+	r.canvas.DrawRectangle(runeX+2, runeY+1, 3, 7, stone)
+	r.canvas.DrawRectangle(runeX+1, runeY+2, 5, 5, stone)
+	r.canvas.DrawRectangle(runeX, runeY+3, 7, 3, stone)
+	glyph := rl.Color{R: uint8(60 + glow*60), G: uint8(80 + glow*170), B: uint8(100 + glow*155), A: 255}
+	r.canvas.DrawRectangle(runeX+3, runeY+2, 1, 5, glyph)
+	r.canvas.DrawRectangle(runeX+2, runeY+3, 1, 1, glyph)
+	r.canvas.DrawRectangle(runeX+4, runeY+5, 1, 1, glyph)
+
+	// Sparkline of recent per-turn hit ratios, newest next to the rune
+	sparkH := int32(8)
+	sparkRight := runeX - 4
+	bg := rl.Color{R: 20, G: 18, B: 30, A: 160}
+	r.canvas.DrawRectangle(sparkRight-cacheHistoryLen, runeY, cacheHistoryLen, sparkH+1, bg)
+	for i, ratio := range cache.History {
+		x := sparkRight - int32(len(cache.History)-i)
+		h := int32(ratio*float32(sparkH)) + 1
+		color := rl.Color{R: 80, G: 200, B: 240, A: 255}
+		if ratio < 0.5 {
+			color = rl.Color{R: 220, G: 120, B: 80, A: 255}
+		}
+		r.canvas.DrawRectangle(x, runeY+sparkH+1-h, 1, h, color)
+	}
+
+	// Session hit ratio as a percentage
+	sessionText := fmt.Sprintf("%d%%", int(state.Session.Tokens.CacheHitRatio()*100))
+	textWidth := r.canvas.MeasureText(sessionText, 6)
+	r.canvas.DrawText(sessionText, sparkRight-cacheHistoryLen-textWidth-3, runeY+1, 6, rl.Color{R: 120, G: 115, B: 140, A: 255})
 }
 
 // drawFlowMeter renders the flow meter (vertical bar on right side)
@@ -525,8 +719,8 @@ func (r *Renderer) drawFlowMeter(state *GameState) {
 	// Background
 	bgColor := rl.Color{R: 20, G: 18, B: 30, A: 200}
 	borderColor := rl.Color{R: 50, G: 45, B: 70, A: 255}
-	rl.DrawRectangle(barX-1, barY-1, barWidth+2, barHeight+2, borderColor)
-	rl.DrawRectangle(barX, barY, barWidth, barHeight, bgColor)
+	r.canvas.DrawRectangle(barX-1, barY-1, barWidth+2, barHeight+2, borderColor)
+	r.canvas.DrawRectangle(barX, barY, barWidth, barHeight, bgColor)
 
 	// Calculate fill (bottom to top)
 	fillHeight := int32(float32(barHeight-2) * state.Session.FlowMeter)
@@ -556,22 +750,22 @@ func (r *Renderer) drawFlowMeter(state *GameState) {
 	// Draw fill from bottom
 	if fillHeight > 0 {
 		fillY := barY + barHeight - 1 - fillHeight
-		rl.DrawRectangle(barX+1, fillY, barWidth-2, fillHeight, fillColor)
+		r.canvas.DrawRectangle(barX+1, fillY, barWidth-2, fillHeight, fillColor)
 	}
 
 	// Label
 	labelColor := rl.Color{R: 100, G: 95, B: 120, A: 255}
-	rl.DrawText("F", barX+1, barY-10, 8, labelColor)
+	r.canvas.DrawText("F", barX+1, barY-10, 8, labelColor)
 
 	// Peak indicator
 	if state.Session.FlowPeakReached {
 		peakColor := rl.Color{R: 255, G: 200, B: 80, A: 200}
-		rl.DrawText("*", barX+barWidth+1, barY-2, 8, peakColor)
+		r.canvas.DrawText("*", barX+barWidth+1, barY-2, 8, peakColor)
 	}
 }
 
 // wordWrap splits text into lines that fit within maxWidth
-func wordWrap(text string, fontSize int32, maxWidth int32) []string {
+func (r *Renderer) wordWrap(text string, fontSize int32, maxWidth int32) []string {
 	words := strings.Fields(text)
 	if len(words) == 0 {
 		return nil
@@ -587,7 +781,7 @@ func wordWrap(text string, fontSize int32, maxWidth int32) []string {
 		}
 		testLine += word
 
-		if rl.MeasureText(testLine, fontSize) <= maxWidth {
+		if r.canvas.MeasureText(testLine, fontSize) <= maxWidth {
 			currentLine = testLine
 		} else {
 			if currentLine != "" {
@@ -622,9 +816,39 @@ func (r *Renderer) drawFloatingXPs(state *GameState) {
 		y := int32(xp.Y)
 
 		// Shadow
-		rl.DrawText(text, x+1, y+1, 8, shadowColor)
+		r.canvas.DrawText(text, x+1, y+1, 8, shadowColor)
 		// Main text
-		rl.DrawText(text, x, y, 8, xpColor)
+		r.canvas.DrawText(text, x, y, 8, xpColor)
+	}
+}
+
+// drawFloatingDiffs renders "+42 / -7" edit sizes like damage numbers,
+// bigger for bigger edits
+func (r *Renderer) drawFloatingDiffs(state *GameState) {
+	for _, d := range state.FloatingDiffs {
+		alpha := uint8(255 * (1 - d.Timer/d.MaxLife))
+		size := int32(8)
+		if d.Added+d.Removed >= 50 {
+			size = 10
+		}
+
+		parts := []struct {
+			text  string
+			color rl.Color
+		}{
+			{fmt.Sprintf("+%d", d.Added), rl.Color{R: 110, G: 230, B: 110, A: alpha}},
+			{" / ", rl.Color{R: 200, G: 200, B: 210, A: alpha}},
+			{fmt.Sprintf("-%d", d.Removed), rl.Color{R: 240, G: 100, B: 90, A: alpha}},
+		}
+		if d.Removed == 0 {
+			parts = parts[:1]
+		}
+		x, y := int32(d.X), int32(d.Y)
+		for _, part := range parts {
+			r.canvas.DrawText(part.text, x+1, y+1, size, rl.Color{R: 0, G: 0, B: 0, A: alpha / 2})
+			r.canvas.DrawText(part.text, x, y, size, part.color)
+			x += r.canvas.MeasureText(part.text, size)
+		}
 	}
 }
 
@@ -644,7 +868,7 @@ func (r *Renderer) DrawTreasureChest(state *GameState) {
 			overlayAlpha = 180
 		}
 	}
-	rl.DrawRectangle(0, 0, screenWidth, screenHeight, rl.Color{R: 10, G: 8, B: 20, A: overlayAlpha})
+	r.canvas.DrawRectangle(0, 0, screenWidth, screenHeight, rl.Color{R: 10, G: 8, B: 20, A: overlayAlpha})
 
 	// Chest position (center of screen) - chest is 64px wide when scaled 2x
 	chestX := int32(screenWidth/2 - 32)
@@ -695,10 +919,10 @@ func (r *Renderer) DrawTreasureChest(state *GameState) {
 			Height: 64,
 		}
 
-		rl.DrawTexturePro(r.chestTexture, srcRect, destRect, rl.Vector2{}, 0, rl.White)
+		r.canvas.DrawTexturePro(r.chestTexture, srcRect, destRect, rl.Vector2{}, 0, rl.White)
 	} else {
 		// Fallback: draw a simple rectangle
-		rl.DrawRectangle(chestX+wobbleOffset, chestY, 64, 48, rl.Color{R: 139, G: 69, B: 19, A: 255})
+		r.canvas.DrawRectangle(chestX+wobbleOffset, chestY, 64, 48, rl.Color{R: 139, G: 69, B: 19, A: 255})
 	}
 
 	// Spawn sparkle particles when chest is opening or revealing
@@ -738,24 +962,24 @@ func (r *Renderer) DrawTreasureChest(state *GameState) {
 
 			// Draw box
 			boxH := int32(32)
-			rl.DrawRectangle(itemX-1, itemY-1, boxW+2, boxH+2, borderColor)
-			rl.DrawRectangle(itemX, itemY, boxW, boxH, boxColor)
+			r.canvas.DrawRectangle(itemX-1, itemY-1, boxW+2, boxH+2, borderColor)
+			r.canvas.DrawRectangle(itemX, itemY, boxW, boxH, boxColor)
 
 			// Item name (truncate if needed)
 			textColor := rl.Color{R: 220, G: 215, B: 240, A: boxAlpha}
 			nameText := item.Name
 			// Measure and truncate to fit
-			for len(nameText) > 0 && rl.MeasureText(nameText, 6) > boxW-4 {
+			for len(nameText) > 0 && r.canvas.MeasureText(nameText, 6) > boxW-4 {
 				nameText = nameText[:len(nameText)-1]
 			}
-			textW := rl.MeasureText(nameText, 6)
-			rl.DrawText(nameText, itemX+(boxW-textW)/2, itemY+6, 6, textColor)
+			textW := r.canvas.MeasureText(nameText, 6)
+			r.canvas.DrawText(nameText, itemX+(boxW-textW)/2, itemY+6, 6, textColor)
 
 			// Slot type (smaller, below name)
 			slotText := string(item.Slot)
 			slotColor := rl.Color{R: 150, G: 145, B: 170, A: boxAlpha}
-			slotW := rl.MeasureText(slotText, 6)
-			rl.DrawText(slotText, itemX+(boxW-slotW)/2, itemY+18, 6, slotColor)
+			slotW := r.canvas.MeasureText(slotText, 6)
+			r.canvas.DrawText(slotText, itemX+(boxW-slotW)/2, itemY+18, 6, slotColor)
 		}
 	}
 
@@ -765,6 +989,9 @@ func (r *Renderer) DrawTreasureChest(state *GameState) {
 	if chest.Type == ChestTypeLevelUp {
 		titleText = "LEVEL UP!"
 		titleColor = rl.Color{R: 255, G: 215, B: 0, A: 255} // Gold
+	} else if chest.Type == ChestTypeDrop {
+		titleText = "RARE DROP!"
+		titleColor = rl.Color{R: 200, G: 130, B: 255, A: 255} // Purple
 	} else {
 		titleText = "BONUS!"
 		titleColor = rl.Color{R: 100, G: 255, B: 150, A: 255} // Green
@@ -773,20 +1000,20 @@ func (r *Renderer) DrawTreasureChest(state *GameState) {
 	// Pulsing title
 	pulse := float32(1.0 + 0.15*simpleSinF(float64(chest.Timer*6)))
 	titleFontSize := int32(14 * pulse)
-	titleWidth := rl.MeasureText(titleText, titleFontSize)
+	titleWidth := r.canvas.MeasureText(titleText, titleFontSize)
 	titleX := (screenWidth - titleWidth) / 2
 	titleY := int32(15)
 
 	// Shadow
-	rl.DrawText(titleText, titleX+1, titleY+1, titleFontSize, rl.Color{R: 0, G: 0, B: 0, A: 200})
-	rl.DrawText(titleText, titleX, titleY, titleFontSize, titleColor)
+	r.canvas.DrawText(titleText, titleX+1, titleY+1, titleFontSize, rl.Color{R: 0, G: 0, B: 0, A: 200})
+	r.canvas.DrawText(titleText, titleX, titleY, titleFontSize, titleColor)
 
 	// Instructions when choosing
 	if chest.State == ChestChoosing {
 		instructText := "< > select   ENTER claim"
-		instructW := rl.MeasureText(instructText, 6)
+		instructW := r.canvas.MeasureText(instructText, 6)
 		instructColor := rl.Color{R: 150, G: 145, B: 170, A: 200}
-		rl.DrawText(instructText, (screenWidth-instructW)/2, chestY+70, 6, instructColor)
+		r.canvas.DrawText(instructText, (screenWidth-instructW)/2, chestY+70, 6, instructColor)
 	}
 
 	// "NEW ITEM!" celebration when claiming
@@ -795,26 +1022,26 @@ func (r *Renderer) DrawTreasureChest(state *GameState) {
 		celebAlpha := uint8((1.0 - claimProgress) * 255)
 
 		celebText := fmt.Sprintf("NEW: %s!", chest.ClaimedItem.Name)
-		celebW := rl.MeasureText(celebText, 10)
+		celebW := r.canvas.MeasureText(celebText, 10)
 		celebX := (screenWidth - celebW) / 2
 		celebY := int32(90 - claimProgress*20)
 
 		celebColor := rl.Color{R: 255, G: 215, B: 0, A: celebAlpha}
 		shadowColor := rl.Color{R: 0, G: 0, B: 0, A: celebAlpha / 2}
 
-		rl.DrawText(celebText, celebX+1, celebY+1, 10, shadowColor)
-		rl.DrawText(celebText, celebX, celebY, 10, celebColor)
+		r.canvas.DrawText(celebText, celebX+1, celebY+1, 10, shadowColor)
+		r.canvas.DrawText(celebText, celebX, celebY, 10, celebColor)
 	}
 
 	// Handle empty pool case
 	if chest.State >= ChestRevealing && !chest.HasItems() {
 		noItemsText := "All items unlocked!"
-		noItemsW := rl.MeasureText(noItemsText, 8)
-		rl.DrawText(noItemsText, (screenWidth-noItemsW)/2, 50, 8, rl.Color{R: 180, G: 175, B: 200, A: 255})
+		noItemsW := r.canvas.MeasureText(noItemsText, 8)
+		r.canvas.DrawText(noItemsText, (screenWidth-noItemsW)/2, 50, 8, rl.Color{R: 180, G: 175, B: 200, A: 255})
 
 		bonusText := "+500 XP Bonus!"
-		bonusW := rl.MeasureText(bonusText, 10)
+		bonusW := r.canvas.MeasureText(bonusText, 10)
 		bonusColor := rl.Color{R: 255, G: 200, B: 80, A: 255}
-		rl.DrawText(bonusText, (screenWidth-bonusW)/2, 65, 10, bonusColor)
+		r.canvas.DrawText(bonusText, (screenWidth-bonusW)/2, 65, 10, bonusColor)
 	}
 }
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// rainbowColors are the rainbow's bands, outside in
+var rainbowColors = []rl.Color{
+	{R: 230, G: 60, B: 60, A: 255},
+	{R: 240, G: 140, B: 50, A: 255},
+	{R: 245, G: 220, B: 70, A: 255},
+	{R: 90, G: 200, B: 90, A: 255},
+	{R: 70, G: 150, B: 230, A: 255},
+	{R: 90, G: 80, B: 200, A: 255},
+	{R: 150, G: 80, B: 190, A: 255},
+}
+
+// drawWeather draws the session's weather over the biome. Clouds and rain
+// roll in and out rather than switching on and off.
+func (r *Renderer) drawWeather() {
+	dt := renderFrameTime()
+	w := &r.weather
+
+	kind := w.Kind()
+	clouds, rain := float32(0), float32(0)
+	switch kind {
+	case WeatherCloudy:
+		clouds = 0.3 + w.ErrorRate()
+	case WeatherRain:
+		clouds, rain = 0.8, 0.5
+	case WeatherStorm:
+		clouds, rain = 1, 1
+	}
+	ease := dt * 0.5
+	if ease > 1 {
+		ease = 1
+	}
+	r.weatherClouds += (clouds - r.weatherClouds) * ease
+	r.weatherRain += (rain - r.weatherRain) * ease
+
+	// Overcast: the whole scene dims under the clouds
+	if r.weatherClouds > 0.01 {
+		r.canvas.DrawRectangle(0, 0, screenWidth, screenHeight, rl.Color{R: 30, G: 35, B: 50, A: uint8(110 * r.weatherClouds)})
+		r.drawWeatherClouds(kind == WeatherStorm)
+	}
+
+	r.updateRain(dt)
+	r.drawRain()
+
+	if kind == WeatherStorm {
+		r.updateLightning(dt)
+	}
+	if r.lightning > 0 {
+		r.drawLightning()
+		r.lightning -= dt * 4
+	}
+
+	if w.rainbow > 0 {
+		r.drawRainbow()
+	}
+}
+
+// drawWeatherClouds draws a band of drifting clouds, more of them the worse it gets
+func (r *Renderer) drawWeatherClouds(storm bool) {
+	time := renderTime()
+	color := rl.Color{R: 95, G: 100, B: 115, A: uint8(220 * r.weatherClouds)}
+	if storm {
+		color = rl.Color{R: 55, G: 55, B: 70, A: uint8(235 * r.weatherClouds)}
+	}
+	count := int(r.weatherClouds*8 + 0.5)
+	for i := 0; i < count; i++ {
+		span := float64(screenWidth + 100)
+		x := math.Mod(float64(i*83)+time*(4+float64(i%3)*2)+float64(r.scrollOffset)*0.05, span)
+		cx := int32(x) - 50
+		cy := int32(10 + (i*17)%25)
+		r.canvas.DrawEllipse(cx, cy, 30, 10, color)
+		r.canvas.DrawEllipse(cx-18, cy+4, 18, 8, color)
+		r.canvas.DrawEllipse(cx+20, cy+3, 20, 8, color)
+	}
+}
+
+// updateRain spawns and moves raindrops
+func (r *Renderer) updateRain(dt float32) {
+	// Spawn at a steady rate, carrying fractions of a drop between frames
+	r.rainDue += r.weatherRain * 350 * dt
+	for ; r.rainDue >= 1; r.rainDue-- {
+		r.weatherDrops = append(r.weatherDrops, Particle{
+			X:       r.rng.Float32()*(screenWidth+40) - 20,
+			Y:       -r.rng.Float32() * 20,
+			VX:      -20 * r.weatherRain, // Wind picks up in a storm
+			VY:      200 + r.rng.Float32()*80,
+			Life:    1,
+			MaxLife: 1,
+			Color:   rl.Color{R: 160, G: 180, B: 230, A: 190},
+		})
+	}
+
+	alive := r.weatherDrops[:0]
+	for i := range r.weatherDrops {
+		p := &r.weatherDrops[i]
+		p.X += p.VX * dt
+		p.Y += p.VY * dt
+		p.Life -= dt
+		// Drops land somewhere on the ground
+		if p.Life > 0 && p.Y < 165+float32(int(p.VY)%30) {
+			alive = append(alive, *p)
+		}
+	}
+	r.weatherDrops = alive
+}
+
+// drawRain draws each drop as a short streak
+func (r *Renderer) drawRain() {
+	for _, p := range r.weatherDrops {
+		x, y := int32(p.X), int32(p.Y)
+		r.canvas.DrawLine(x, y, x+int32(p.VX*0.02), y+5, p.Color)
+	}
+}
+
+// updateLightning strikes every few seconds in a storm
+func (r *Renderer) updateLightning(dt float32) {
+	if r.lightning <= 0 && r.rng.Float32() < dt*0.35 {
+		r.lightning = 1
+		r.lightningX = 40 + r.rng.Int31n(screenWidth-80)
+		r.lightningSeed = r.rng.Uint64()
+	}
+}
+
+// drawLightning flashes the sky and draws the bolt
+func (r *Renderer) drawLightning() {
+	flash := rl.Color{R: 230, G: 230, B: 255, A: uint8(120 * r.lightning)}
+	r.canvas.DrawRectangle(0, 0, screenWidth, screenHeight, flash)
+
+	// The bolt zigzags down from the clouds, the same shape for the whole strike
+	bolt := rl.Color{R: 255, G: 255, B: 220, A: uint8(255 * r.lightning)}
+	x, y := r.lightningX, int32(20)
+	seed := r.lightningSeed
+	for y < 150 {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		nx := x + int32(seed>>60) - 8
+		ny := y + 12 + int32((seed>>40)&7)
+		r.canvas.DrawLine(x, y, nx, ny, bolt)
+		r.canvas.DrawLine(x+1, y, nx+1, ny, bolt)
+		x, y = nx, ny
+	}
+}
+
+// drawRainbow arcs a rainbow across the sky, fading in and out
+func (r *Renderer) drawRainbow() {
+	left := r.weather.rainbow
+	alpha := float32(1)
+	if shown := weatherRainbowTime - left; shown < 2 {
+		alpha = shown / 2
+	}
+	if left < 3 {
+		alpha = left / 3
+	}
+
+	cx, cy := float64(230), float64(165)
+	for band, color := range rainbowColors {
+		color.A = uint8(140 * alpha)
+		radius := float64(95 - band*2)
+		steps := int(math.Pi * radius)
+		for s := 0; s <= steps; s++ {
+			angle := math.Pi * float64(s) / float64(steps)
+			x := int32(cx - radius*math.Cos(angle))
+			y := int32(cy - radius*math.Sin(angle))
+			r.canvas.DrawRectangle(x, y, 1, 2, color)
+		}
+	}
+}
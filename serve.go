@@ -0,0 +1,336 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// OVERLAY SERVER (cq serve)
+// ============================================================================
+//
+// Runs the game headless (no window, no GL context) and streams it to a
+// browser-source overlay over WebSocket. The overlay page draws Claude and
+// the HUD on a transparent canvas, and each element can be its own OBS
+// source via ?layers=.
+
+const (
+	defaultServePort = 7878
+	serveTickRate    = 30 // Game updates (and state broadcasts) per second
+)
+
+//go:embed overlay/index.html
+var overlayPage []byte
+
+// OverlayEvent is an Event as sent to the overlay
+type OverlayEvent struct {
+	Type    string `json:"type"`
+	Details string `json:"details"`
+	Tool    string `json:"tool,omitempty"`
+	IsError bool   `json:"is_error,omitempty"`
+}
+
+// OverlayState is a snapshot of everything the overlay draws
+type OverlayState struct {
+	Anim       int     `json:"anim"`
+	AnimName   string  `json:"anim_name"`
+	Frame      int     `json:"frame"`
+	Active     bool    `json:"active"`
+	Level      int     `json:"level"`
+	XP         int     `json:"xp"`
+	XPProgress float32 `json:"xp_progress"`
+
+	ManaUsed  int `json:"mana_used"`
+	ManaMax   int `json:"mana_max"`
+	TurnsLeft int `json:"turns_left"`
+
+	Flow      float32 `json:"flow"`
+	Quest     string  `json:"quest"`
+	QuestFade float32 `json:"quest_fade"`
+	Thought   string  `json:"thought"`
+
+	Cost       float64 `json:"cost"`
+	OverBudget bool    `json:"over_budget"`
+	Stamina    float32 `json:"stamina"`
+	ResetIn    int     `json:"reset_in"` // Seconds until the usage window resets (0 if none)
+	Resting    bool    `json:"resting"`
+
+	CacheTurn    float32   `json:"cache_turn"`
+	CacheSession float32   `json:"cache_session"`
+	CacheHistory []float32 `json:"cache_history"`
+
+	Agents  int  `json:"agents"`
+	Shipped bool `json:"shipped"`
+}
+
+// overlayMessage wraps everything sent over the WebSocket
+type overlayMessage struct {
+	Kind  string        `json:"kind"` // "event" or "state"
+	Event *OverlayEvent `json:"event,omitempty"`
+	State *OverlayState `json:"state,omitempty"`
+}
+
+// overlayHub fans messages out to every connected overlay
+type overlayHub struct {
+	mu      sync.Mutex
+	clients map[*wsConn]chan []byte
+}
+
+// add registers a client and returns its outgoing queue
+func (h *overlayHub) add(c *wsConn) chan []byte {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	queue := make(chan []byte, 64)
+	h.clients[c] = queue
+	return queue
+}
+
+// remove unregisters a client
+func (h *overlayHub) remove(c *wsConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if queue, ok := h.clients[c]; ok {
+		close(queue)
+		delete(h.clients, c)
+	}
+}
+
+// broadcast queues a message for every client, dropping it for slow ones
+func (h *overlayHub) broadcast(msg overlayMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, queue := range h.clients {
+		select {
+		case queue <- data:
+		default:
+		}
+	}
+}
+
+// loopbackOnly refuses requests whose Host isn't the loopback address, which
+// keeps DNS-rebound pages away from the overlay and its assets
+func loopbackOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			http.Error(w, "unexpected Host", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serveWebSocket upgrades an overlay connection and streams messages to it
+func (h *overlayHub) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	queue := h.add(conn)
+
+	go func() {
+		conn.readLoop()
+		h.remove(conn)
+		conn.Close()
+	}()
+
+	for data := range queue {
+		if err := conn.WriteText(data); err != nil {
+			h.remove(conn)
+			conn.Close()
+			return
+		}
+	}
+}
+
+// overlayEventFrom normalizes a watcher event for the overlay
+func overlayEventFrom(e Event) *OverlayEvent {
+	return &OverlayEvent{
+		Type:    e.Type.String(),
+		Details: e.Details,
+		Tool:    e.ToolName,
+		IsError: e.IsError,
+	}
+}
+
+// overlayStateFrom snapshots the game for the overlay
+func overlayStateFrom(anim *AnimationState, g *GameState) *OverlayState {
+	s := &OverlayState{
+		Anim:       int(anim.CurrentAnim),
+		AnimName:   anim.CurrentAnim.String(),
+		Frame:      anim.Frame,
+		Active:     g.IsActive,
+		ManaUsed:   int(g.ManaDisplay),
+		ManaMax:    g.ManaMax,
+		TurnsLeft:  g.Forecast.TurnsLeft(g.ManaTotal, g.ManaMax),
+		Flow:       g.Session.FlowMeter,
+		QuestFade:  g.QuestFade,
+		Cost:       g.SessionCost,
+		OverBudget: g.Budgets.OverBudget(),
+		Stamina:    g.Usage.Stamina(),
+		Resting:    g.Resting,
+		Agents:     len(g.MiniAgents),
+		Shipped:    g.ShippedActive,
+
+		CacheTurn:    g.Cache.Turn,
+		CacheSession: g.Session.Tokens.CacheHitRatio(),
+		CacheHistory: g.Cache.History,
+	}
+	if g.QuestText != "" {
+		s.Quest = g.QuestText
+	}
+	if g.ThoughtFade > 0 {
+		s.Thought = truncate(g.ThoughtText, 80)
+	}
+	if g.Profile != nil {
+		s.Level = g.Profile.Level
+		s.XP = g.Profile.XP
+		s.XPProgress = g.Profile.XPProgress()
+	}
+	resetAt := g.Usage.ResetAt
+	if g.Resting {
+		resetAt = g.Usage.LimitedUntil
+	}
+	if !resetAt.IsZero() {
+		s.ResetIn = int(time.Until(resetAt).Seconds())
+	}
+	return s
+}
+
+// runServe starts the overlay server and runs the game headless
+func runServe(args []string) {
+	port := defaultServePort
+	dir := "."
+	replayFile := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-p", "--port":
+			if i+1 < len(args) {
+				if p, err := strconv.Atoi(args[i+1]); err == nil {
+					port = p
+				}
+				i++
+			}
+		case "--replay":
+			if i+1 < len(args) {
+				replayFile = args[i+1]
+				i++
+			}
+		default:
+			dir = args[i]
+		}
+	}
+
+	watcher := NewWatcher()
+	var err error
+	if replayFile != "" {
+		err = watcher.StartReplay(replayFile)
+	} else {
+		err = watcher.FindProjectConversation(dir)
+		if err == nil {
+			err = watcher.StartLive()
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	hub := &overlayHub{clients: make(map[*wsConn]chan []byte)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(overlayPage)
+	})
+	mux.HandleFunc("/ws", hub.serveWebSocket)
+	mux.Handle("/assets/", http.StripPrefix("/assets/", http.FileServer(http.Dir(getAssetPath("")))))
+
+	// Loopback only - the overlay is for the local streaming software
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Watching: %s\n", watcher.FilePath)
+	fmt.Printf("Overlay:  http://%s/\n", listener.Addr())
+	fmt.Println("Add it to OBS as a Browser source (320x200, or a multiple).")
+	fmt.Println("Use ?layers=claude,quest,mana,xp,level,gold,cache,stamina,flow,events to pick elements, ?scale=N to resize.")
+
+	go func() {
+		if err := http.Serve(listener, loopbackOnly(mux)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}()
+
+	runHeadless(watcher, hub)
+}
+
+// runHeadless drives the game loop without a window, broadcasting to overlays
+func runHeadless(watcher *Watcher, hub *overlayHub) {
+	config := LoadConfig("config.json")
+	if config.Privacy == "" {
+		// Anything on the overlay is on stream
+		config.Privacy = PrivacyRedact
+	}
+	animations := NewAnimationSystem()
+	gameState := NewGameState(config)
+
+	usage := NewUsageTracker(time.Duration(config.UsageWindowHours*float64(time.Hour)), config.UsageWindowLimit)
+	usage.Start()
+
+	ticker := time.NewTicker(time.Second / serveTickRate)
+	defer ticker.Stop()
+	last := time.Now()
+
+	for now := range ticker.C {
+		dt := float32(now.Sub(last).Seconds())
+		last = now
+
+		// Process every pending event - there's no frame budget to protect
+	drain:
+		for {
+			select {
+			case event := <-watcher.Events:
+				animations.HandleEvent(event)
+				gameState.HandleEvent(event)
+				hub.broadcast(overlayMessage{Kind: "event", Event: overlayEventFrom(gameState.Privacy.Event(event))})
+			default:
+				break drain
+			}
+		}
+
+		gameState.SetUsage(usage.Snapshot())
+		animations.Update(dt)
+		gameState.Update(dt)
+		animations.SetActive(gameState.IsActive)
+
+		if gameState.PendingHurt {
+			gameState.PendingHurt = false
+			animations.HandleEvent(Event{Type: EventEnemyHit})
+		}
+
+		// Nobody can pick from a chest on stream - take the first item
+		if gameState.ActiveChest != nil && gameState.ActiveChest.IsInteractive() {
+			gameState.ActiveChest.ConfirmSelection()
+		}
+
+		hub.broadcast(overlayMessage{Kind: "state", State: overlayStateFrom(animations.GetState(), gameState)})
+	}
+}
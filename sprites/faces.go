@@ -141,12 +141,12 @@ func GenerateMouthTexture(state MouthState) *image.RGBA {
 
 	switch state {
 	case MouthSmile:
-		// Curved smile
-		img.Set(1, 0, MouthColor)
-		img.Set(2, 0, MouthColor)
-		img.Set(3, 0, MouthColor)
-		img.Set(0, 1, MouthColor)
-		img.Set(4, 1, MouthColor)
+		// Curved smile, corners up
+		img.Set(0, 0, MouthColor)
+		img.Set(4, 0, MouthColor)
+		img.Set(1, 1, MouthColor)
+		img.Set(2, 1, MouthColor)
+		img.Set(3, 1, MouthColor)
 
 	case MouthOpen:
 		// Hollow "o"
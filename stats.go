@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// statsDays is how many recent days `cq stats` lists
+const statsDays = 14
+
+// formatTokens shortens a token count for display (e.g. 1.2M, 340k)
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000_000:
+		return fmt.Sprintf("%.2fB", float64(n)/1e9)
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 10_000:
+		return fmt.Sprintf("%dk", n/1000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// formatTokenTotals describes totals broken down by kind
+func formatTokenTotals(t TokenTotals) string {
+	return fmt.Sprintf("%s (input %s, cache write %s, cache read %s, output %s)",
+		formatTokens(t.Total()), formatTokens(t.Input), formatTokens(t.CacheCreation),
+		formatTokens(t.CacheRead), formatTokens(t.Output))
+}
+
+// runStats prints the career profile's lifetime and recent daily stats
+func runStats() {
+	p := LoadProfile()
+
+	fmt.Println("Claude Quest Stats")
+	fmt.Println("==================")
+	fmt.Println()
+
+	fmt.Printf("  Level %d, %d XP (%d to next level)\n", p.Level, p.XP, p.XPToNextLevel())
+	if !p.FirstSeen.IsZero() {
+		fmt.Printf("  Adventuring since %s, %d sessions\n", p.FirstSeen.Local().Format("2006-01-02"), p.SessionsStarted)
+	}
+	fmt.Println()
+
+	fmt.Printf("  Reads:               %d\n", p.TotalReads)
+	fmt.Printf("  Writes:              %d\n", p.TotalWrites)
+	fmt.Printf("  Bash:                %d (%d succeeded)\n", p.TotalBash, p.BashSuccesses)
+	fmt.Printf("  Todos completed:     %d\n", p.TodosCompleted)
+	fmt.Printf("  Agents completed:    %d\n", p.AgentsCompleted)
+	fmt.Println()
+
+	printLanguages(p)
+
+	fmt.Println("Tokens")
+	fmt.Println()
+	fmt.Printf("  %-12s %10s %10s %10s %10s %10s\n", "", "Input", "CacheWrite", "CacheRead", "Output", "Total")
+	printTokenRow := func(label string, t TokenTotals) {
+		fmt.Printf("  %-12s %10s %10s %10s %10s %10s\n", label,
+			formatTokens(t.Input), formatTokens(t.CacheCreation), formatTokens(t.CacheRead),
+			formatTokens(t.Output), formatTokens(t.Total()))
+	}
+	printTokenRow("Lifetime", p.Tokens)
+	fmt.Printf("\n  Prompt cache hit ratio: %d%%\n\n", int(p.Tokens.CacheHitRatio()*100))
+
+	var days []string
+	for day := range p.TokensByDay {
+		days = append(days, day)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(days)))
+	if len(days) > statsDays {
+		days = days[:statsDays]
+	}
+	for _, day := range days {
+		printTokenRow(day, p.TokensByDay[day])
+	}
+	if len(days) == 0 {
+		fmt.Println("  No token usage recorded yet.")
+	}
+	fmt.Println()
+
+	printCosts(p, LoadConfig("config.json"), days)
+}
+
+// printCosts prints estimated costs by model and by day
+func printCosts(p *CareerProfile, config *Config, days []string) {
+	fmt.Println("Estimated cost (USD)")
+	fmt.Println()
+	fmt.Printf("  %-28s %9s %9s %9s %9s %9s\n", "", "Input", "CacheWrt", "CacheRead", "Output", "Total")
+	printCostRow := func(label string, c CostBreakdown) {
+		fmt.Printf("  %-28s %9s %9s %9s %9s %9s\n", label,
+			formatCost(c.Input), formatCost(c.CacheWrite), formatCost(c.CacheRead),
+			formatCost(c.Output), formatCost(c.Total()))
+	}
+
+	byModel := p.ModelTokens()
+	var models []string
+	for model := range byModel {
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return config.Cost(models[i], byModel[models[i]]).Total() > config.Cost(models[j], byModel[models[j]]).Total()
+	})
+	for _, model := range models {
+		label := model
+		if _, ok := config.PriceFor(model); !ok {
+			label += " (no price)"
+		}
+		printCostRow(label, config.Cost(model, byModel[model]))
+	}
+	printCostRow("Lifetime", config.CostOf(byModel))
+	fmt.Println()
+
+	for _, day := range days {
+		printCostRow(day, config.CostOf(p.ModelTokensByDay[day]))
+	}
+}
+
+// printLanguages lists the languages Claude has worked in, most edited first
+func printLanguages(p *CareerProfile) {
+	if len(p.Languages) == 0 {
+		return
+	}
+	var langs []string
+	for lang := range p.Languages {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		a, b := p.Languages[langs[i]], p.Languages[langs[j]]
+		if a.Edits != b.Edits {
+			return a.Edits > b.Edits
+		}
+		return a.Reads > b.Reads
+	})
+
+	fmt.Println("Languages")
+	fmt.Println()
+	fmt.Printf("  %-12s %8s %8s %10s\n", "", "Reads", "Edits", "Lines")
+	for _, lang := range langs {
+		stats := p.Languages[lang]
+		fmt.Printf("  %-12s %8d %8d %10d\n", lang, stats.Reads, stats.Edits, stats.Lines)
+	}
+	fmt.Println()
+}
@@ -37,7 +37,7 @@ func runStudio() {
 	speed := float32(1.0)
 	currentAnim := 0
 	showHelp := true
-	biomeNames := []string{"Forest", "Mountain", "Midnight", "Kingdom", "Library"}
+	biomeNames := renderer.BiomeNames()
 
 	// Picker state: 0=none, 1=animation, 2=biome, 3=hat, 4=face, 5=aura, 6=trail
 	pickerMode := 0
@@ -56,6 +56,7 @@ func runStudio() {
 		}
 
 		renderer.UpdateScrollOnly(dt)
+		renderer.Update(dt)
 		renderer.UpdatePickerAnim(dt)
 
 		// Hot reload
@@ -6,6 +6,7 @@ type ChestType int
 const (
 	ChestTypeLevelUp ChestType = iota
 	ChestTypeBonus
+	ChestTypeDrop // A rare language drop
 )
 
 // ChestState represents the current state in the chest ceremony
@@ -68,6 +69,16 @@ func NewBonusChest(profile *CareerProfile, reason string) *TreasureChest {
 	}
 }
 
+// NewDropChest creates a chest holding a rare language drop
+func NewDropChest(item Item) *TreasureChest {
+	return &TreasureChest{
+		Type:   ChestTypeDrop,
+		State:  ChestClosed,
+		Items:  []Item{item},
+		Reason: item.Language,
+	}
+}
+
 // Update advances the chest animation state machine
 func (c *TreasureChest) Update(dt float32) {
 	c.Timer += dt
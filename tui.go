@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ============================================================================
+// TERMINAL MODE (cq tui)
+// ============================================================================
+//
+// Runs the game without a window: the scene is drawn on a software canvas and
+// printed with half-block characters, each cell showing two pixels in 24-bit
+// color. The picture is scaled to fit the terminal, and the HUD is plain text
+// underneath it.
+
+const (
+	tuiFPS      = 15
+	tuiHUDLines = 2
+)
+
+// runTUI parses the tui command line and runs the game in the terminal
+func runTUI(args []string) {
+	dir := "."
+	replayFile := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--replay":
+			if i+1 < len(args) {
+				replayFile = args[i+1]
+				i++
+			}
+		default:
+			dir = args[i]
+		}
+	}
+
+	if _, _, err := terminalSize(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error: cq tui needs an interactive terminal")
+		os.Exit(1)
+	}
+
+	watcher := NewWatcher()
+	var err error
+	if replayFile != "" {
+		err = watcher.StartReplay(replayFile)
+	} else {
+		err = watcher.FindProjectConversation(dir)
+		if err == nil {
+			err = watcher.StartLive()
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := playInTerminal(watcher); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// playInTerminal runs the game loop until q, Ctrl-C or a termination signal
+func playInTerminal(watcher *Watcher) error {
+	config := LoadConfig("config.json")
+	canvas := NewImageCanvas(screenWidth, screenHeight)
+	renderer := NewRendererOn(config, canvas)
+	defer renderer.Unload()
+	animations := NewAnimationSystem()
+	gameState := NewGameState(config)
+	defer gameState.SaveDungeon()
+	renderer.SetProfile(gameState.Profile)
+
+	usage := NewUsageTracker(time.Duration(config.UsageWindowHours*float64(time.Hour)), config.UsageWindowLimit)
+	usage.Start()
+
+	restore, err := enterRawMode()
+	if err != nil {
+		return err
+	}
+	// Alternate screen, hidden cursor; put everything back on the way out
+	os.Stdout.WriteString("\x1b[?1049h\x1b[?25l\x1b[2J")
+	defer func() {
+		os.Stdout.WriteString("\x1b[0m\x1b[?25h\x1b[?1049l")
+		restore()
+	}()
+
+	quit := make(chan struct{}, 1)
+	go readQuitKeys(quit)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	// The renderer runs off the fixed clock, fed the real frame time
+	fixedTime = 0
+	defer func() { fixedFrameTime = 0 }()
+
+	ticker := time.NewTicker(time.Second / tuiFPS)
+	defer ticker.Stop()
+	last := time.Now()
+	var cols, rows int
+	var out bytes.Buffer
+
+	for {
+		select {
+		case <-quit:
+			return nil
+		case <-signals:
+			return nil
+		case now := <-ticker.C:
+			dt := float32(now.Sub(last).Seconds())
+			last = now
+			fixedFrameTime = dt
+
+		drain:
+			for {
+				select {
+				case event := <-watcher.Events:
+					animations.HandleEvent(event)
+					gameState.HandleEvent(event)
+					renderer.HandleEvent(event)
+				default:
+					break drain
+				}
+			}
+
+			gameState.SetUsage(usage.Snapshot())
+			animations.Update(dt)
+			gameState.Update(dt)
+			renderer.Update(dt)
+			animations.SetActive(gameState.IsActive)
+			if gameState.PendingHurt {
+				gameState.PendingHurt = false
+				animations.HandleEvent(Event{Type: EventEnemyHit})
+			}
+			if gameState.IsActive {
+				renderer.UpdateScroll(dt)
+			}
+			// There's no chest UI in the terminal - take the first item
+			if gameState.ActiveChest != nil && gameState.ActiveChest.IsInteractive() {
+				gameState.ActiveChest.ConfirmSelection()
+			}
+
+			canvas.Clear(sceneBackground)
+			renderer.Draw(animations.GetState())
+			stepRenderClock()
+
+			// Clear everything when the terminal is resized
+			c, r, err := terminalSize()
+			if err != nil {
+				return err
+			}
+			out.Reset()
+			if c != cols || r != rows {
+				cols, rows = c, r
+				out.WriteString("\x1b[0m\x1b[2J")
+			}
+			writeHalfBlocks(&out, canvas.Image, cols, rows-tuiHUDLines)
+			writeTUIHUD(&out, gameState, cols, rows)
+			os.Stdout.Write(out.Bytes())
+		}
+	}
+}
+
+// readQuitKeys signals quit when q, Q or Ctrl-C is typed
+func readQuitKeys(quit chan<- struct{}) {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, b := range buf[:n] {
+			if b == 'q' || b == 'Q' || b == 3 {
+				quit <- struct{}{}
+				return
+			}
+		}
+	}
+}
+
+// writeHalfBlocks scales img to fit cols x rows cells (two pixels per cell,
+// top and bottom), centers it and writes it as colored half blocks
+func writeHalfBlocks(out *bytes.Buffer, img *image.RGBA, cols, rows int) {
+	srcW, srcH := img.Rect.Dx(), img.Rect.Dy()
+	if cols < 1 || rows < 1 {
+		return
+	}
+	// Largest size that fits while keeping the aspect ratio
+	w, h := cols, cols*srcH/srcW
+	if h > rows*2 {
+		w, h = rows*2*srcW/srcH, rows*2
+	}
+	h &^= 1
+	if w < 1 || h < 2 {
+		return
+	}
+	left := (cols-w)/2 + 1
+	top := (rows-h/2)/2 + 1
+
+	var fg, bg rl.Color
+	for cy := 0; cy < h/2; cy++ {
+		fmt.Fprintf(out, "\x1b[%d;%dH", top+cy, left)
+		for cx := 0; cx < w; cx++ {
+			upper := boxSample(img, cx*srcW/w, (cy*2)*srcH/h, (cx+1)*srcW/w, (cy*2+1)*srcH/h)
+			lower := boxSample(img, cx*srcW/w, (cy*2+1)*srcH/h, (cx+1)*srcW/w, (cy*2+2)*srcH/h)
+			// Only send colors that changed since the previous cell
+			if cx == 0 || upper != fg {
+				fg = upper
+				fmt.Fprintf(out, "\x1b[38;2;%d;%d;%dm", fg.R, fg.G, fg.B)
+			}
+			if cx == 0 || lower != bg {
+				bg = lower
+				fmt.Fprintf(out, "\x1b[48;2;%d;%d;%dm", bg.R, bg.G, bg.B)
+			}
+			out.WriteString("▀")
+		}
+		out.WriteString("\x1b[0m")
+	}
+}
+
+// boxSample averages the pixels in [x0,x1) x [y0,y1), or takes the pixel at
+// (x0,y0) when the box is empty (upscaling)
+func boxSample(img *image.RGBA, x0, y0, x1, y1 int) rl.Color {
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	var r, g, b, n int
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			p := img.RGBAAt(x, y)
+			r += int(p.R)
+			g += int(p.G)
+			b += int(p.B)
+			n++
+		}
+	}
+	return rl.Color{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 255}
+}
+
+// writeTUIHUD writes the stats and the current quest on the bottom lines
+func writeTUIHUD(out *bytes.Buffer, g *GameState, cols, rows int) {
+	stats := ""
+	if g.Profile != nil {
+		stats = fmt.Sprintf("Lv.%d  XP %d (%d%%)  ", g.Profile.Level, g.Profile.XP, int(g.Profile.XPProgress()*100))
+	}
+	stats += fmt.Sprintf("Mana %s/%s", formatTokens(int64(g.ManaDisplay)), formatTokens(int64(g.ManaMax)))
+	if turns := g.Forecast.TurnsLeft(g.ManaTotal, g.ManaMax); turns > 0 {
+		stats += fmt.Sprintf(" ~%d turns", turns)
+	}
+	stats += fmt.Sprintf("  %s  Cache %d%%  Stamina %d%%",
+		formatCost(g.SessionCost), int(g.Session.Tokens.CacheHitRatio()*100), int(g.Usage.Stamina()*100))
+
+	quest := g.QuestText
+	if g.ThoughtFade > 0 && g.ThoughtText != "" {
+		quest = g.ThoughtText
+	}
+
+	for i, line := range []string{stats, quest} {
+		fmt.Fprintf(out, "\x1b[%d;1H\x1b[2K", rows-tuiHUDLines+1+i)
+		out.WriteString(fitToWidth(line, cols))
+	}
+}
+
+// fitToWidth flattens text to one line and cuts it to at most cols runes
+func fitToWidth(s string, cols int) string {
+	runes := []rune(strings.Join(strings.Fields(s), " "))
+	if len(runes) <= cols {
+		return string(runes)
+	}
+	if cols <= 3 {
+		return string(runes[:max(cols, 0)])
+	}
+	return string(runes[:cols-3]) + "..."
+}
@@ -0,0 +1,43 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// terminalSize returns the size of the console window in cells
+func terminalSize() (int, int, error) {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(os.Stdout.Fd()), &info); err != nil {
+		return 0, 0, err
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1, nil
+}
+
+// enterRawMode turns on escape sequences for stdout and raw key input for
+// stdin. Ctrl-C arrives as a key press.
+func enterRawMode() (func(), error) {
+	in, out := windows.Handle(os.Stdin.Fd()), windows.Handle(os.Stdout.Fd())
+	var inMode, outMode uint32
+	if err := windows.GetConsoleMode(in, &inMode); err != nil {
+		return nil, err
+	}
+	if err := windows.GetConsoleMode(out, &outMode); err != nil {
+		return nil, err
+	}
+	if err := windows.SetConsoleMode(out, outMode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+		return nil, err
+	}
+	raw := inMode &^ (windows.ENABLE_ECHO_INPUT | windows.ENABLE_LINE_INPUT | windows.ENABLE_PROCESSED_INPUT)
+	if err := windows.SetConsoleMode(in, raw); err != nil {
+		windows.SetConsoleMode(out, outMode)
+		return nil, err
+	}
+	return func() {
+		windows.SetConsoleMode(in, inMode)
+		windows.SetConsoleMode(out, outMode)
+	}, nil
+}
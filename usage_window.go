@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// ROLLING USAGE WINDOW (STAMINA)
+// ============================================================================
+//
+// Plan limits apply to a rolling window shared by every session on the
+// machine, not to one conversation. The tracker tails all transcripts under
+// the config dir in the background and groups usage into windows the way the
+// plan does: a window opens on the hour of the first message after the
+// previous one closed, and lasts the configured duration.
+
+const (
+	defaultUsageWindow = 5 * time.Hour
+	usageScanInterval  = 30 * time.Second
+	usageHistory       = 30 * 24 * time.Hour // How far back to look for the busiest window
+)
+
+// rateLimitPattern matches Claude Code's rate-limit notice. Older versions
+// append the reset time as a unix timestamp ("Claude AI usage limit reached|1735000000").
+var rateLimitPattern = regexp.MustCompile(`(?i)limit reached(?:\|(\d+))?`)
+
+// UsageWindow is a snapshot of the current rolling usage window
+type UsageWindow struct {
+	Used         int64     // Tokens used in the current window (input, cache writes, output)
+	Limit        int64     // Window capacity: configured, or the busiest past window
+	ResetAt      time.Time // When the current window closes (zero if none is open)
+	LimitedUntil time.Time // Rate limited until this time (zero if not limited)
+}
+
+// Stamina returns the fraction of the window left (1 = fresh, 0 = spent)
+func (u UsageWindow) Stamina() float32 {
+	if u.Limit <= 0 || u.ResetAt.IsZero() {
+		return 1
+	}
+	left := 1 - float32(u.Used)/float32(u.Limit)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// Exhausted returns true while a rate limit is in force
+func (u UsageWindow) Exhausted(now time.Time) bool {
+	return now.Before(u.LimitedUntil)
+}
+
+// usageEntry is the largest usage seen for one assistant message
+type usageEntry struct {
+	Time   time.Time
+	Tokens int64
+}
+
+// UsageTracker tails every transcript in the background
+type UsageTracker struct {
+	window time.Duration
+	limit  int64 // Configured capacity (0 = estimate from history)
+
+	mu       sync.Mutex
+	snapshot UsageWindow
+
+	// Scan state, only touched by the scanning goroutine
+	offsets      map[string]int64
+	messages     map[string]usageEntry
+	limitedUntil time.Time // Reset time announced by a rate-limit notice
+	limitNotice  time.Time // Latest notice without a reset time
+}
+
+// NewUsageTracker creates a tracker for a rolling window of the given length
+func NewUsageTracker(window time.Duration, limit int64) *UsageTracker {
+	if window <= 0 {
+		window = defaultUsageWindow
+	}
+	return &UsageTracker{
+		window:   window,
+		limit:    limit,
+		offsets:  make(map[string]int64),
+		messages: make(map[string]usageEntry),
+	}
+}
+
+// Start scans all transcripts now and then keeps polling in the background
+func (t *UsageTracker) Start() {
+	go func() {
+		ticker := time.NewTicker(usageScanInterval)
+		defer ticker.Stop()
+		for {
+			t.scan()
+			<-ticker.C
+		}
+	}()
+}
+
+// Snapshot returns the latest usage window state
+func (t *UsageTracker) Snapshot() UsageWindow {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshot
+}
+
+// scan reads new transcript lines and recomputes the window
+func (t *UsageTracker) scan() {
+	configDir, err := claudeConfigDir()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	horizon := now.Add(-usageHistory)
+
+	// Subagent transcripts count too, so walk everything
+	filepath.WalkDir(filepath.Join(configDir, "projects"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".jsonl") {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.ModTime().Before(horizon) {
+			return nil
+		}
+		t.readFile(path, now)
+		return nil
+	})
+
+	// Forget messages too old to matter
+	for id, entry := range t.messages {
+		if entry.Time.Before(horizon) {
+			delete(t.messages, id)
+		}
+	}
+
+	snapshot := t.compute(now)
+	t.mu.Lock()
+	t.snapshot = snapshot
+	t.mu.Unlock()
+}
+
+// readFile reads the lines appended to a transcript since the last scan
+func (t *UsageTracker) readFile(path string, now time.Time) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.Size() <= t.offsets[path] {
+		return
+	}
+	if _, err := file.Seek(t.offsets[path], io.SeekStart); err != nil {
+		return
+	}
+
+	reader := bufio.NewReader(file)
+	offset := t.offsets[path]
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break // Leave a partial last line for the next scan
+		}
+		offset += int64(len(line))
+		t.parseLine(line, now)
+	}
+	t.offsets[path] = offset
+}
+
+// parseLine records the usage or rate limit notice in one transcript line
+func (t *UsageTracker) parseLine(line string, now time.Time) {
+	isUsage := strings.Contains(line, `"usage"`)
+	isLimit := strings.Contains(line, "limit reached")
+	if !isUsage && !isLimit {
+		return
+	}
+
+	var msg struct {
+		Type       string `json:"type"`
+		UUID       string `json:"uuid"`
+		Timestamp  string `json:"timestamp"`
+		IsAPIError bool   `json:"isApiErrorMessage"`
+		Message    struct {
+			ID      string          `json:"id"`
+			Model   string          `json:"model"`
+			Content json.RawMessage `json:"content"`
+			Usage   *TokenUsage     `json:"usage"`
+		} `json:"message"`
+	}
+	if json.Unmarshal([]byte(line), &msg) != nil || msg.Type != "assistant" {
+		return
+	}
+	ts, err := time.Parse(time.RFC3339Nano, msg.Timestamp)
+	if err != nil {
+		return
+	}
+
+	// Only Claude Code's own notices count, not Claude talking about limits
+	if isLimit && (msg.IsAPIError || msg.Message.Model == "<synthetic>") {
+		if m := rateLimitPattern.FindSubmatch(msg.Message.Content); m != nil {
+			t.recordRateLimit(ts, m[1], now)
+		}
+	}
+
+	if u := msg.Message.Usage; u != nil {
+		id := msg.Message.ID
+		if id == "" {
+			id = "uuid:" + msg.UUID
+		}
+		tokens := int64(u.InputTokens + u.CacheCreationTokens + u.OutputTokens)
+		if prev, ok := t.messages[id]; !ok || tokens > prev.Tokens {
+			t.messages[id] = usageEntry{Time: ts, Tokens: tokens}
+		}
+	}
+}
+
+// recordRateLimit notes a rate-limit notice. Without an announced reset time
+// the limit lasts until the window the notice fell in closes.
+func (t *UsageTracker) recordRateLimit(ts time.Time, resetEpoch []byte, now time.Time) {
+	epoch, err := strconv.ParseInt(string(resetEpoch), 10, 64)
+	if err != nil {
+		if ts.After(t.limitNotice) {
+			t.limitNotice = ts
+		}
+		return
+	}
+	if until := time.Unix(epoch, 0); until.After(now) && until.After(t.limitedUntil) {
+		t.limitedUntil = until
+	}
+}
+
+// compute groups usage into windows and describes the one open now
+func (t *UsageTracker) compute(now time.Time) UsageWindow {
+	entries := make([]usageEntry, 0, len(t.messages))
+	for _, entry := range t.messages {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+
+	var start time.Time
+	var used, busiest int64
+	for _, entry := range entries {
+		if start.IsZero() || !entry.Time.Before(start.Add(t.window)) {
+			// Previous window closed - remember how full it got
+			if used > busiest {
+				busiest = used
+			}
+			start = entry.Time.Truncate(time.Hour)
+			used = 0
+		}
+		used += entry.Tokens
+	}
+
+	var snapshot UsageWindow
+	if !start.IsZero() && now.Before(start.Add(t.window)) {
+		snapshot.Used = used
+		snapshot.ResetAt = start.Add(t.window)
+		if !t.limitNotice.Before(start) {
+			snapshot.LimitedUntil = snapshot.ResetAt
+		}
+	} else if used > busiest {
+		busiest = used
+	}
+
+	snapshot.Limit = t.limit
+	if snapshot.Limit <= 0 {
+		snapshot.Limit = busiest
+	}
+	if now.Before(t.limitedUntil) && t.limitedUntil.After(snapshot.LimitedUntil) {
+		snapshot.LimitedUntil = t.limitedUntil
+	}
+	return snapshot
+}
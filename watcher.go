@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -38,6 +39,33 @@ const (
 	EventGitPush       // Git push detected - SHIPPED! rainbow effect
 )
 
+func (e EventType) String() string {
+	names := []string{
+		"system_init",
+		"thinking",
+		"reading",
+		"bash",
+		"writing",
+		"success",
+		"error",
+		"idle",
+		"quest",
+		"compact",
+		"think_hard",
+		"spawn_agent",
+		"agent_complete",
+		"todo_update",
+		"ask_user",
+		"enemy_hit",
+		"victory_pose",
+		"git_push",
+	}
+	if int(e) < len(names) {
+		return names[e]
+	}
+	return "unknown"
+}
+
 // TokenUsage tracks context window usage for mana bar
 type TokenUsage struct {
 	InputTokens         int `json:"input_tokens"`
@@ -71,6 +99,7 @@ type Event struct {
 
 	// Extended data for game mechanics
 	TokenUsage   *TokenUsage  // For mana bar
+	MessageUsage *TokenUsage  // The line's own usage, for accounting (nil if it has none)
 	TodoItems    []TodoItem   // For todo display
 	CompactInfo  *CompactInfo // For compact/sleep
 	ToolName     string       // Original tool name
@@ -78,12 +107,24 @@ type Event struct {
 	IsError      bool         // Whether this was an error
 	ThinkLevel   ThinkLevel   // For think hard effects
 	ThoughtText  string       // Claude's thinking content (for thought bubble)
+	MessageID    string       // Assistant message ID (usage is counted once per message)
+	Model        string       // Model that produced the message
+	Timestamp    time.Time    // When the transcript line was written (zero if unknown)
+	Cwd          string       // Working directory Claude Code was running in
+	TestRun      bool         // Bash command runs a test suite
+	Commit       string       // First line of the message of a git commit
+	Path         string       // File or directory a file tool works on
+	LinesAdded   int          // Lines an edit adds...
+	LinesRemoved int          // ...and removes
 }
 
 // ClaudeMessage represents the structure of Claude Code JSONL format
 type ClaudeMessage struct {
-	Type    string `json:"type"`
-	Subtype string `json:"subtype,omitempty"`
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype,omitempty"`
+	UUID      string `json:"uuid,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Cwd       string `json:"cwd,omitempty"`
 
 	// For system messages
 	CompactMetadata *CompactInfo `json:"compactMetadata,omitempty"`
@@ -93,6 +134,7 @@ type ClaudeMessage struct {
 
 	// Message content
 	Message struct {
+		ID      string `json:"id,omitempty"`
 		Role    string `json:"role"`
 		Model   string `json:"model,omitempty"`
 		Content json.RawMessage `json:"content"` // Can be string or array
@@ -412,6 +454,15 @@ func (w *Watcher) parseLine(line string) []Event {
 		}
 	}
 
+	if ts, err := time.Parse(time.RFC3339Nano, msg.Timestamp); err == nil {
+		for i := range events {
+			events[i].Timestamp = ts
+		}
+	}
+	for i := range events {
+		events[i].Cwd = msg.Cwd
+	}
+
 	return events
 }
 
@@ -458,6 +509,7 @@ func (w *Watcher) parseAssistantMessage(msg ClaudeMessage) []Event {
 			evt := w.parseToolUse(item)
 			if evt != nil {
 				evt.TokenUsage = w.LastTokenUsage
+				evt.ToolUseID = item.ID
 				events = append(events, *evt)
 			}
 
@@ -486,6 +538,18 @@ func (w *Watcher) parseAssistantMessage(msg ClaudeMessage) []Event {
 		}
 	}
 
+	// Tag events with their message so usage is only counted once, even
+	// though each content block is logged as its own line
+	messageID := msg.Message.ID
+	if messageID == "" {
+		messageID = "uuid:" + msg.UUID
+	}
+	for i := range events {
+		events[i].MessageID = messageID
+		events[i].Model = msg.Message.Model
+		events[i].MessageUsage = msg.Message.Usage
+	}
+
 	return events
 }
 
@@ -523,9 +587,10 @@ func (w *Watcher) parseUserMessage(msg ClaudeMessage) []Event {
 					errorDetails = truncate(string(item.Content), 40)
 				}
 				events = append(events, Event{
-					Type:    EventError,
-					Details: errorDetails,
-					IsError: true,
+					Type:      EventError,
+					Details:   errorDetails,
+					IsError:   true,
+					ToolUseID: item.ToolUseID,
 				})
 			}
 		}
@@ -617,6 +682,148 @@ func (w *Watcher) extractUserPromptText(raw json.RawMessage) string {
 	return ""
 }
 
+// testCommands are the ways a test suite is usually run
+var testCommands = []string{
+	"go test", "npm test", "npm run test", "yarn test", "pnpm test", "bun test", "deno test",
+	"npx jest", "npx vitest", "pytest", "python -m pytest", "python -m unittest", "tox",
+	"cargo test", "make test", "make check", "mvn test", "gradle test", "./gradlew test",
+	"dotnet test", "mix test", "rspec", "bundle exec rspec", "rake test", "phpunit", "ctest",
+}
+
+// isTestCommand reports whether a (lowercased) shell command runs tests
+func isTestCommand(cmd string) bool {
+	for _, part := range strings.FieldsFunc(cmd, func(r rune) bool { return r == '&' || r == ';' || r == '|' }) {
+		part = strings.TrimSpace(part)
+		for _, test := range testCommands {
+			if part == test || strings.HasPrefix(part, test+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toolPath returns the file or directory a file tool works on ("" if none)
+func toolPath(input json.RawMessage) string {
+	var fileInput struct {
+		FilePath     string `json:"file_path"`
+		NotebookPath string `json:"notebook_path"`
+		Path         string `json:"path"`
+	}
+	if err := json.Unmarshal(input, &fileInput); err != nil {
+		return ""
+	}
+	switch {
+	case fileInput.FilePath != "":
+		return fileInput.FilePath
+	case fileInput.NotebookPath != "":
+		return fileInput.NotebookPath
+	}
+	return fileInput.Path
+}
+
+// editLines returns how many lines an Edit, MultiEdit, Write or NotebookEdit
+// adds and removes. A Write counts its whole content as added, since the old
+// file isn't in the transcript.
+func editLines(toolName string, input json.RawMessage) (int, int) {
+	type replacement struct {
+		OldString string `json:"old_string"`
+		NewString string `json:"new_string"`
+	}
+	var edit struct {
+		replacement
+		Content   string        `json:"content"`
+		NewSource string        `json:"new_source"`
+		Edits     []replacement `json:"edits"`
+	}
+	if err := json.Unmarshal(input, &edit); err != nil {
+		return 0, 0
+	}
+
+	switch toolName {
+	case "write":
+		return len(splitLines(edit.Content)), 0
+	case "notebookedit":
+		return len(splitLines(edit.NewSource)), 0
+	case "multiedit":
+		added, removed := 0, 0
+		for _, e := range edit.Edits {
+			a, r := lineDiff(e.OldString, e.NewString)
+			added += a
+			removed += r
+		}
+		return added, removed
+	}
+	return lineDiff(edit.OldString, edit.NewString)
+}
+
+// lineDiff counts the lines that differ between two versions of a snippet,
+// leaving out the lines they start and end with in common
+func lineDiff(before, after string) (int, int) {
+	was, now := splitLines(before), splitLines(after)
+	for len(was) > 0 && len(now) > 0 && was[0] == now[0] {
+		was, now = was[1:], now[1:]
+	}
+	for len(was) > 0 && len(now) > 0 && was[len(was)-1] == now[len(now)-1] {
+		was, now = was[:len(was)-1], now[:len(now)-1]
+	}
+	return len(now), len(was)
+}
+
+// splitLines splits text into lines, without an empty one after a final newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// commitFlagPattern finds the message flag of a git commit (-m, -am, --message)
+var commitFlagPattern = regexp.MustCompile(`\bgit\s+(?:-C\s+\S+\s+)?commit\b[^\n|;&]*?\s(?:-[a-zA-Z]*m|--message)(?:=|\s*)`)
+
+// commitMessage returns the first line of the message a shell command commits
+// with, or "" if it doesn't commit with one
+func commitMessage(cmd string) string {
+	loc := commitFlagPattern.FindStringIndex(cmd)
+	if loc == nil {
+		return ""
+	}
+	rest := cmd[loc[1]:]
+
+	var msg string
+	switch {
+	case strings.HasPrefix(rest, `"$(cat <<`):
+		// Heredoc: the message starts on the next line
+		if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+			msg = rest[nl+1:]
+		}
+	case strings.HasPrefix(rest, "'"):
+		msg = rest[1:]
+		if end := strings.IndexByte(msg, '\''); end >= 0 {
+			msg = msg[:end]
+		}
+	case strings.HasPrefix(rest, `"`):
+		var b strings.Builder
+		for i := 1; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				i++
+			}
+			b.WriteByte(rest[i])
+		}
+		msg = b.String()
+	default:
+		if fields := strings.Fields(rest); len(fields) > 0 {
+			msg = fields[0]
+		}
+	}
+
+	msg = strings.TrimSpace(msg)
+	if nl := strings.IndexByte(msg, '\n'); nl >= 0 {
+		msg = strings.TrimSpace(msg[:nl])
+	}
+	return msg
+}
+
 // parseToolUse handles tool_use content items
 func (w *Watcher) parseToolUse(item ContentItem) *Event {
 	toolName := strings.ToLower(item.Name)
@@ -624,7 +831,7 @@ func (w *Watcher) parseToolUse(item ContentItem) *Event {
 	switch {
 	// Reading tools
 	case toolName == "glob" || toolName == "read" || toolName == "grep":
-		return &Event{Type: EventReading, Details: "Reading files", ToolName: item.Name}
+		return &Event{Type: EventReading, Details: "Reading files", ToolName: item.Name, Path: toolPath(item.Input)}
 
 	case toolName == "websearch" || toolName == "webfetch":
 		return &Event{Type: EventReading, Details: "Searching web", ToolName: item.Name}
@@ -637,8 +844,15 @@ func (w *Watcher) parseToolUse(item ContentItem) *Event {
 		}
 		if err := json.Unmarshal(item.Input, &bashInput); err == nil {
 			cmd := strings.ToLower(bashInput.Command)
+			commit := commitMessage(bashInput.Command)
 			if strings.Contains(cmd, "git push") || strings.Contains(cmd, "git push") {
-				return &Event{Type: EventGitPush, Details: "SHIPPED!", ToolName: item.Name}
+				return &Event{Type: EventGitPush, Details: "SHIPPED!", ToolName: item.Name, Commit: commit}
+			}
+			if commit != "" {
+				return &Event{Type: EventBash, Details: "Committing", ToolName: item.Name, Commit: commit}
+			}
+			if isTestCommand(cmd) {
+				return &Event{Type: EventBash, Details: "Running tests", ToolName: item.Name, TestRun: true}
 			}
 		}
 		return &Event{Type: EventBash, Details: "Running command", ToolName: item.Name}
@@ -647,8 +861,10 @@ func (w *Watcher) parseToolUse(item ContentItem) *Event {
 		return &Event{Type: EventBash, Details: "Stopping process", ToolName: item.Name}
 
 	// Writing tools
-	case toolName == "edit" || toolName == "write" || toolName == "notebookedit":
-		return &Event{Type: EventWriting, Details: "Writing code", ToolName: item.Name}
+	case toolName == "edit" || toolName == "multiedit" || toolName == "write" || toolName == "notebookedit":
+		added, removed := editLines(toolName, item.Input)
+		return &Event{Type: EventWriting, Details: "Writing code", ToolName: item.Name, Path: toolPath(item.Input),
+			LinesAdded: added, LinesRemoved: removed}
 
 	// Task/Agent spawning
 	case toolName == "task":
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCommitMessage(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want string
+	}{
+		{`git commit -m "Fix the login bug"`, "Fix the login bug"},
+		{`git add -A && git commit -m 'Add tests' && git push`, "Add tests"},
+		{`git commit -am "Say \"hi\""`, `Say "hi"`},
+		{`git commit --message=wip`, "wip"},
+		{`git -C api commit --amend -m "Tidy up"`, "Tidy up"},
+		{"git commit -m \"$(cat <<'EOF'\nRefactor the parser\n\nLonger body\nEOF\n)\"", "Refactor the parser"},
+		{`git commit -F msg.txt`, ""},
+		{`git status && echo -m "not a commit"`, ""},
+	}
+	for _, tt := range tests {
+		if got := commitMessage(tt.cmd); got != tt.want {
+			t.Errorf("commitMessage(%q) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
+
+func TestEditLines(t *testing.T) {
+	tests := []struct {
+		tool    string
+		input   string
+		added   int
+		removed int
+	}{
+		{"edit", `{"old_string": "a\nb\nc", "new_string": "a\nB\nc"}`, 1, 1},
+		{"edit", `{"old_string": "func f() {\n}", "new_string": "func f() {\n\treturn\n}"}`, 1, 0},
+		{"edit", `{"old_string": "x := 1\ny := 2\n", "new_string": ""}`, 0, 2},
+		{"write", `{"content": "package main\n\nfunc main() {}\n"}`, 3, 0},
+		{"multiedit", `{"edits": [{"old_string": "a", "new_string": "b\nc"}, {"old_string": "d\ne", "new_string": "d"}]}`, 2, 2},
+		{"notebookedit", `{"new_source": "print(1)\nprint(2)"}`, 2, 0},
+	}
+	for _, tt := range tests {
+		added, removed := editLines(tt.tool, []byte(tt.input))
+		if added != tt.added || removed != tt.removed {
+			t.Errorf("%s %s: +%d / -%d, want +%d / -%d", tt.tool, tt.input, added, removed, tt.added, tt.removed)
+		}
+	}
+
+	if got := WriteXP(0); got != XPWrite {
+		t.Errorf("WriteXP(0) = %d, want %d", got, XPWrite)
+	}
+	if got := WriteXP(100000); got != XPWriteMax {
+		t.Errorf("WriteXP of a huge edit is %d, want the cap %d", got, XPWriteMax)
+	}
+}
+
+func TestUsageCountedOnce(t *testing.T) {
+	w := NewWatcher()
+	lines := []string{
+		`{"type":"assistant","message":{"id":"a","content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":100,"output_tokens":10}}}`,
+		`{"type":"assistant","message":{"id":"a","content":[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"x.go"}}],"usage":{"input_tokens":100,"output_tokens":30}}}`,
+		// A line of a new message without usage must not re-credit the last one's
+		`{"type":"assistant","message":{"id":"b","content":[{"type":"text","text":"more"}]}}`,
+		`{"type":"assistant","message":{"id":"b","content":[{"type":"text","text":"done"}],"usage":{"input_tokens":200,"output_tokens":5}}}`,
+	}
+	var s SessionStats
+	for _, line := range lines {
+		for _, event := range w.parseLine(line) {
+			if event.TokenUsage == nil {
+				t.Errorf("%s event has no usage for the mana bar", event.MessageID)
+			}
+			if event.MessageUsage != nil {
+				s.RecordUsage(event.MessageID, event.Model, event.MessageUsage)
+			}
+		}
+	}
+	if want := (TokenTotals{Input: 300, Output: 35}); s.Tokens != want {
+		t.Errorf("session tokens %+v, want %+v", s.Tokens, want)
+	}
+
+	// Only recent messages are remembered
+	for i := 0; i < 2*usageMessagesKept; i++ {
+		s.RecordUsage("m"+strconv.Itoa(i), "", &TokenUsage{OutputTokens: 1})
+	}
+	if len(s.messageUsage) != usageMessagesKept || len(s.messageOrder) != usageMessagesKept {
+		t.Errorf("remembering %d messages, want %d", len(s.messageUsage), usageMessagesKept)
+	}
+}
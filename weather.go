@@ -0,0 +1,148 @@
+package main
+
+// ============================================================================
+// WEATHER
+// ============================================================================
+//
+// The sky over every biome shows how the session is going. The last few tool
+// calls and their errors make a sliding window: a clean run keeps the sky
+// clear, clouds gather as the error rate climbs, failing tests bring rain and
+// the same error again and again brings a storm. When things recover, a
+// rainbow comes out.
+
+// WeatherKind is the session's mood
+type WeatherKind int
+
+const (
+	WeatherClear WeatherKind = iota
+	WeatherCloudy
+	WeatherRain
+	WeatherStorm
+	WeatherRainbow
+)
+
+const (
+	weatherWindow       = 12   // Tool calls the error rate is taken over
+	weatherCloudyRate   = 0.25 // Error rate that brings clouds
+	weatherStormRepeats = 3    // Identical errors that bring a storm
+	weatherCalmCalls    = 5    // Clean tool calls in a row that end a storm
+	weatherRainbowTime  = 12   // Seconds the rainbow stays out
+)
+
+// weatherOutcome is one tool call in the window
+type weatherOutcome struct {
+	id     string
+	test   bool
+	failed bool
+}
+
+// Weather tracks how the session is going
+type Weather struct {
+	outcomes     []weatherOutcome
+	testsFailing bool    // The last test run failed
+	lastError    string  // Details of the latest error
+	repeats      int     // Times lastError has come up without a calm stretch
+	calm         int     // Clean tool calls since the last error
+	rainbow      float32 // Seconds of rainbow left
+}
+
+// HandleEvent adds a tool call or an error to the window
+func (w *Weather) HandleEvent(event Event) {
+	wasBad := w.bad()
+
+	switch event.Type {
+	case EventReading, EventWriting, EventBash, EventGitPush, EventSpawnAgent, EventTodoUpdate:
+		// Claude only moves on once the last call returned, so a test run
+		// without an error by now passed
+		if n := len(w.outcomes); n > 0 && w.outcomes[n-1].test && !w.outcomes[n-1].failed {
+			w.testsFailing = false
+			w.repeats = 0
+		}
+		w.calm++
+		if w.calm >= weatherCalmCalls {
+			w.repeats = 0
+		}
+		w.outcomes = append(w.outcomes, weatherOutcome{id: event.ToolUseID, test: event.TestRun})
+		if len(w.outcomes) > weatherWindow {
+			w.outcomes = w.outcomes[1:]
+		}
+
+	case EventError:
+		w.fail(event)
+
+	default:
+		return
+	}
+
+	if wasBad && !w.bad() {
+		w.rainbow = weatherRainbowTime
+	}
+}
+
+// fail marks the tool call an error belongs to
+func (w *Weather) fail(event Event) {
+	w.calm = 0
+	if event.Details == w.lastError {
+		w.repeats++
+	} else {
+		w.lastError = event.Details
+		w.repeats = 1
+	}
+
+	// Errors without a tool call ID belong to the latest call
+	for i := len(w.outcomes) - 1; i >= 0; i-- {
+		o := &w.outcomes[i]
+		if (event.ToolUseID != "" && o.id == event.ToolUseID) || (event.ToolUseID == "" && !o.failed) {
+			o.failed = true
+			if o.test {
+				w.testsFailing = true
+			}
+			return
+		}
+	}
+	w.outcomes = append(w.outcomes, weatherOutcome{id: event.ToolUseID, failed: true})
+	if len(w.outcomes) > weatherWindow {
+		w.outcomes = w.outcomes[1:]
+	}
+}
+
+// bad reports whether it's raining
+func (w *Weather) bad() bool {
+	return w.testsFailing || w.repeats >= weatherStormRepeats
+}
+
+// ErrorRate returns the share of recent tool calls that failed
+func (w *Weather) ErrorRate() float32 {
+	if len(w.outcomes) == 0 {
+		return 0
+	}
+	failed := 0
+	for _, o := range w.outcomes {
+		if o.failed {
+			failed++
+		}
+	}
+	return float32(failed) / float32(len(w.outcomes))
+}
+
+// Kind returns the weather right now
+func (w *Weather) Kind() WeatherKind {
+	switch {
+	case w.repeats >= weatherStormRepeats:
+		return WeatherStorm
+	case w.testsFailing:
+		return WeatherRain
+	case w.rainbow > 0:
+		return WeatherRainbow
+	case w.ErrorRate() >= weatherCloudyRate:
+		return WeatherCloudy
+	}
+	return WeatherClear
+}
+
+// Update counts down the rainbow
+func (w *Weather) Update(dt float32) {
+	if w.rainbow > 0 {
+		w.rainbow -= dt
+	}
+}
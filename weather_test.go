@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestWeather(t *testing.T) {
+	var w Weather
+	call := func(id string, test bool) { w.HandleEvent(Event{Type: EventBash, ToolUseID: id, TestRun: test}) }
+	fail := func(id, details string) {
+		w.HandleEvent(Event{Type: EventError, ToolUseID: id, Details: details, IsError: true})
+	}
+	expect := func(want WeatherKind) {
+		t.Helper()
+		if got := w.Kind(); got != want {
+			t.Fatalf("weather is %d, want %d", got, want)
+		}
+	}
+
+	for i := 0; i < 8; i++ {
+		call("", false)
+	}
+	expect(WeatherClear)
+
+	// A few different errors bring clouds
+	call("a", false)
+	fail("a", "file not found")
+	call("b", false)
+	fail("b", "permission denied")
+	call("c", false)
+	fail("c", "syntax error")
+	expect(WeatherCloudy)
+
+	// A failing test run brings rain until the tests pass
+	call("t1", true)
+	fail("t1", "FAIL TestLogin")
+	expect(WeatherRain)
+	call("e", false)
+	expect(WeatherRain)
+
+	// The same failure over and over becomes a storm
+	call("t2", true)
+	fail("t2", "FAIL TestLogin")
+	call("t3", true)
+	fail("t3", "FAIL TestLogin")
+	expect(WeatherStorm)
+
+	// The tests pass: the rain stops and a rainbow comes out
+	call("t4", true)
+	call("f", false)
+	expect(WeatherRainbow)
+	w.Update(weatherRainbowTime)
+	if w.Kind() == WeatherRainbow {
+		t.Fatal("rainbow should fade after a while")
+	}
+
+	// A storm without tests blows over after a calm stretch
+	for i := 0; i < weatherStormRepeats; i++ {
+		call("", false)
+		fail("", "connection refused")
+	}
+	expect(WeatherStorm)
+	for i := 0; i < weatherCalmCalls; i++ {
+		call("", false)
+	}
+	expect(WeatherRainbow)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// MINIMAL WEBSOCKET SERVER (RFC 6455)
+// ============================================================================
+//
+// Just enough WebSocket for the overlay: the server pushes text frames, and
+// reads client frames only to answer pings and notice when the page closes.
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	wsOpText  = 0x1
+	wsOpClose = 0x8
+	wsOpPing  = 0x9
+	wsOpPong  = 0xA
+)
+
+// wsConn is a server-side WebSocket connection
+type wsConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	mu   sync.Mutex // Serializes writes
+}
+
+// upgradeWebSocket performs the WebSocket handshake on an HTTP request
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "expected WebSocket upgrade", http.StatusBadRequest)
+		return nil, errors.New("not a WebSocket request")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, errors.New("missing Sec-WebSocket-Key")
+	}
+
+	// Pages on other sites must not be able to read the session, even by
+	// rebinding their own name to the loopback address
+	if !isLoopbackHost(r.Host) {
+		http.Error(w, "unexpected Host", http.StatusForbidden)
+		return nil, errors.New("non-loopback Host refused")
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+			http.Error(w, "cross-origin WebSocket refused", http.StatusForbidden)
+			return nil, errors.New("cross-origin WebSocket refused")
+		}
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("response does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, rw: rw}, nil
+}
+
+// isLoopbackHost checks that a Host header names the loopback interface, so a
+// DNS-rebound page on another name can't talk to the server
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	} else {
+		host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	}
+	switch strings.ToLower(host) {
+	case "127.0.0.1", "localhost", "::1":
+		return true
+	}
+	return false
+}
+
+// headerContains checks a comma-separated header for a token (case-insensitive)
+func headerContains(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeFrame sends one unmasked, unfragmented frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// WriteText sends a text message
+func (c *wsConn) WriteText(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+// readLoop consumes client frames until the connection closes.
+// Pings are answered; everything else the client sends is ignored.
+func (c *wsConn) readLoop() error {
+	for {
+		var head [2]byte
+		if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+			return err
+		}
+		opcode := head[0] & 0x0F
+		masked := head[1]&0x80 != 0
+		length := uint64(head[1] & 0x7F)
+
+		switch length {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+				return err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+				return err
+			}
+			length = binary.BigEndian.Uint64(ext[:])
+		}
+		if length > 1<<20 {
+			return errors.New("WebSocket frame too large")
+		}
+
+		var mask [4]byte
+		if masked {
+			if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+				return err
+			}
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.rw, payload); err != nil {
+			return err
+		}
+		if masked {
+			for i := range payload {
+				payload[i] ^= mask[i%4]
+			}
+		}
+
+		switch opcode {
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return err
+			}
+		case wsOpClose:
+			c.writeFrame(wsOpClose, nil)
+			return io.EOF
+		}
+	}
+}
+
+// Close closes the underlying connection
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}